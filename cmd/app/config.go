@@ -0,0 +1,56 @@
+package app
+
+import (
+	"errors"
+	"fifth_exam/job_service/internal/pkg/config"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the effective configuration",
+}
+
+var configPrintCmd = &cobra.Command{
+	Use:   "print",
+	Short: "Print the effective configuration with secrets redacted",
+	Long: `Example :
+		go run cmd/main.go config print --config config.yaml
+
+	Every key is printed with its value, the environment variable it can be set
+	from and the source of the value (default, file, env or flag). Values that
+	can't be parsed and validation problems are listed after the table.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		// the configuration is printed with the values that could be parsed
+		// even when loading it reported problems
+		cfg, err := config.Load(cmd.Flags())
+		var errValidation *config.ValidationError
+		if err != nil && !errors.As(err, &errValidation) {
+			log.Fatalf("error while loading config: %s", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "KEY\tVALUE\tSOURCE\tENV")
+		for _, entry := range cfg.Entries() {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", entry.Key, entry.Value, entry.Source, entry.Env)
+		}
+		w.Flush()
+
+		if errValidation != nil {
+			fmt.Println()
+			fmt.Println(errValidation.Error())
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configPrintCmd)
+	rootCmd.AddCommand(configCmd)
+}
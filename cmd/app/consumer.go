@@ -27,7 +27,7 @@ var consumerCmd = &cobra.Command{
 
 		switch consumerName { // switch statement based on consumerNames
 		case JOB_CREATE_CONSUMER:
-			JobCreateConsumerRun()
+			JobCreateConsumerRun(loadConfig(cmd))
 		default:
 			log.Fatalf("No consumer with name '%s'", consumerName)
 		}
@@ -38,9 +38,7 @@ func init() {
 	rootCmd.AddCommand(consumerCmd)
 }
 
-func JobCreateConsumerRun() {
-	config := config.New()
-
+func JobCreateConsumerRun(config *config.Config) {
 	app, err := app.NewJobConsumer(config)
 	if err != nil {
 		log.Fatal(err)
@@ -0,0 +1,165 @@
+package app
+
+import (
+	"fifth_exam/job_service/internal/pkg/postgres"
+	"fifth_exam/job_service/migrations"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Apply, revert and inspect the embedded database migrations",
+}
+
+var migrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply every pending migration",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		migrator := newMigrator(cmd)
+		defer migrator.Close()
+
+		if err := migrator.Up(); err != nil {
+			log.Fatalf("migrate up: %s", err)
+		}
+		printVersion(migrator)
+	},
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Revert applied migrations, the last one by default",
+	Long: `Example :
+		go run cmd/main.go migrate down
+		go run cmd/main.go migrate down --steps 2
+		go run cmd/main.go migrate down --all`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		steps, _ := cmd.Flags().GetInt("steps")
+		all, _ := cmd.Flags().GetBool("all")
+		if all {
+			steps = 0
+		} else if steps < 1 {
+			log.Fatal("--steps must be at least 1, use --all to revert every migration")
+		}
+
+		migrator := newMigrator(cmd)
+		defer migrator.Close()
+
+		if err := migrator.Down(steps); err != nil {
+			log.Fatalf("migrate down: %s", err)
+		}
+		printVersion(migrator)
+	},
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "List the embedded migrations and whether they are applied",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		migrator := newMigrator(cmd)
+		defer migrator.Close()
+
+		statuses, err := migrator.Status()
+		if err != nil {
+			log.Fatalf("migrate status: %s", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATUS")
+		for _, status := range statuses {
+			state := "pending"
+			if status.Applied {
+				state = "applied"
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, state)
+		}
+		w.Flush()
+
+		fmt.Println()
+		printVersion(migrator)
+	},
+}
+
+var migrateForceCmd = &cobra.Command{
+	Use:   "force VERSION",
+	Short: "Set the schema version without running migrations",
+	Long: `Example :
+		go run cmd/main.go migrate force 1
+
+	Use it to clear the dirty flag after fixing a failed migration by hand.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		version, err := strconv.Atoi(args[0])
+		if err != nil {
+			log.Fatalf("invalid version %q: %s", args[0], err)
+		}
+
+		migrator := newMigrator(cmd)
+		defer migrator.Close()
+
+		if err := migrator.Force(version); err != nil {
+			log.Fatalf("migrate force: %s", err)
+		}
+		printVersion(migrator)
+	},
+}
+
+var migrateCreateCmd = &cobra.Command{
+	Use:   "create NAME",
+	Short: "Create empty up and down migration files",
+	Long: `Example :
+		go run cmd/main.go migrate create add_jobs_status
+
+	The files are written to the migrations directory of the source tree and
+	embedded on the next build.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dir, _ := cmd.Flags().GetString("dir")
+
+		up, down, err := postgres.CreateMigration(dir, args[0])
+		if err != nil {
+			log.Fatalf("migrate create: %s", err)
+		}
+		fmt.Println(up)
+		fmt.Println(down)
+	},
+}
+
+func init() {
+	migrateDownCmd.Flags().Int("steps", 1, "number of migrations to revert")
+	migrateDownCmd.Flags().Bool("all", false, "revert every applied migration")
+	migrateCreateCmd.Flags().String("dir", "migrations", "directory of the migration files")
+
+	migrateCmd.AddCommand(migrateUpCmd, migrateDownCmd, migrateStatusCmd, migrateForceCmd, migrateCreateCmd)
+	rootCmd.AddCommand(migrateCmd)
+}
+
+func newMigrator(cmd *cobra.Command) *postgres.Migrator {
+	config := loadConfig(cmd)
+
+	migrator, err := postgres.NewMigrator(config, migrations.FS)
+	if err != nil {
+		log.Fatalf("migrate: %s", err)
+	}
+	return migrator
+}
+
+func printVersion(migrator *postgres.Migrator) {
+	version, dirty, err := migrator.Version()
+	if err != nil {
+		log.Fatalf("migrate version: %s", err)
+	}
+	if dirty {
+		fmt.Printf("version %d (dirty)\n", version)
+		return
+	}
+	fmt.Printf("version %d\n", version)
+}
@@ -0,0 +1,179 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fifth_exam/job_service/internal/app"
+	"fifth_exam/job_service/internal/delivery/kafka/handlers"
+	"fifth_exam/job_service/internal/entity"
+	"fifth_exam/job_service/internal/infrastructure/kafka"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var offsetsCmd = &cobra.Command{
+	Use:   "offsets",
+	Short: "Inspect, reset and replay consumer offsets of the job topic",
+}
+
+var offsetsResetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Reset the consumer group offsets of the job topic",
+	Long: `Example :
+		go run cmd/main.go offsets reset --to earliest --dry-run
+		go run cmd/main.go offsets reset --to offset --offset 120
+		go run cmd/main.go offsets reset --to timestamp --timestamp 2024-04-30T10:00:00Z
+
+	The consumer group must be stopped while its offsets are being reset.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		config := loadConfig(cmd)
+
+		flags := cmd.Flags()
+		mode, _ := flags.GetString("to")
+		offset, _ := flags.GetInt64("offset")
+		timestamp, _ := flags.GetString("timestamp")
+		group, _ := flags.GetString("group")
+		dryRun, _ := flags.GetBool("dry-run")
+
+		if group == "" {
+			group = config.Kafka.ConsumerGroup
+		}
+
+		target := kafka.OffsetTarget{Mode: mode, Offset: offset}
+		if timestamp != "" {
+			t, err := time.Parse(time.RFC3339, timestamp)
+			if err != nil {
+				log.Fatalf("invalid --timestamp %q: %v", timestamp, err)
+			}
+			target.Timestamp = t
+		}
+
+		ctx, cancel := signalContext()
+		defer cancel()
+
+		offsets := kafka.NewOffsetManager(config.Kafka.Address)
+
+		var (
+			plan []kafka.PartitionOffset
+			err  error
+		)
+		if dryRun {
+			plan, err = offsets.Plan(ctx, group, config.Kafka.Topic.JobTopic, target)
+		} else {
+			plan, err = offsets.Reset(ctx, group, config.Kafka.Topic.JobTopic, target)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if dryRun {
+			fmt.Printf("dry run, offsets of group %q on topic %q are not changed\n", group, config.Kafka.Topic.JobTopic)
+		} else {
+			fmt.Printf("offsets of group %q on topic %q reset to %s\n", group, config.Kafka.Topic.JobTopic, mode)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "PARTITION\tCURRENT\tTARGET\tFIRST\tLAST")
+		for _, p := range plan {
+			fmt.Fprintf(w, "%d\t%d\t%d\t%d\t%d\n", p.Partition, p.Current, p.Target, p.First, p.Last)
+		}
+		w.Flush()
+	},
+}
+
+var offsetsReplayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Reprocess job topic messages produced in a time window",
+	Long: `Example :
+		go run cmd/main.go offsets replay --from 2024-04-30T10:00:00Z --to 2024-04-30T12:00:00Z --dry-run
+
+	Replay reads the topic outside of the consumer group. With --dry-run messages
+	are only decoded and validated, nothing is written to Postgres, and invalid
+	ones are reported as failed. Otherwise jobs that fail to be created are
+	reported as failed, while invalid jobs and jobs that already exist are
+	reported as skipped, as the consumer commits them without retrying.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		config := loadConfig(cmd)
+
+		flags := cmd.Flags()
+		fromStr, _ := flags.GetString("from")
+		toStr, _ := flags.GetString("to")
+		dryRun, _ := flags.GetBool("dry-run")
+
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			log.Fatalf("invalid --from %q: %v", fromStr, err)
+		}
+		var to time.Time
+		if toStr != "" {
+			if to, err = time.Parse(time.RFC3339, toStr); err != nil {
+				log.Fatalf("invalid --to %q: %v", toStr, err)
+			}
+		}
+
+		ctx, cancel := signalContext()
+		defer cancel()
+
+		var stats kafka.ReplayStats
+		if dryRun {
+			offsets := kafka.NewOffsetManager(config.Kafka.Address)
+			stats, err = offsets.Replay(ctx, config.Kafka.Topic.JobTopic, from, to, handlers.ValidateJobCreate)
+		} else {
+			consumer, cerr := app.NewJobConsumer(config)
+			if cerr != nil {
+				log.Fatal(cerr)
+			}
+			defer consumer.Close()
+			stats, err = consumer.Replay(ctx, from, to)
+		}
+
+		fmt.Printf("read %d, succeeded %d, failed %d, skipped %d\n", stats.Read, stats.Succeeded, len(stats.Failures), len(stats.Skipped))
+		for _, f := range stats.Failures {
+			fmt.Printf("partition %d offset %d: %s\n", f.Partition, f.Offset, describeReplayError(f.Err))
+		}
+		for _, f := range stats.Skipped {
+			fmt.Printf("partition %d offset %d skipped: %s\n", f.Partition, f.Offset, describeReplayError(f.Err))
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	offsetsResetCmd.Flags().String("to", "", "reset target: earliest, latest, offset or timestamp")
+	offsetsResetCmd.Flags().Int64("offset", 0, "offset to reset to when --to=offset")
+	offsetsResetCmd.Flags().String("timestamp", "", "RFC3339 time to reset to when --to=timestamp")
+	offsetsResetCmd.Flags().String("group", "", "consumer group, defaults to the configured job consumer group")
+	offsetsResetCmd.Flags().Bool("dry-run", false, "print the planned offsets without committing them")
+	offsetsResetCmd.MarkFlagRequired("to")
+
+	offsetsReplayCmd.Flags().String("from", "", "RFC3339 start of the replay window")
+	offsetsReplayCmd.Flags().String("to", "", "RFC3339 end of the replay window, defaults to the current end of the topic")
+	offsetsReplayCmd.Flags().Bool("dry-run", false, "decode and validate messages without writing to Postgres")
+	offsetsReplayCmd.MarkFlagRequired("from")
+
+	offsetsCmd.AddCommand(offsetsResetCmd, offsetsReplayCmd)
+	rootCmd.AddCommand(offsetsCmd)
+}
+
+func signalContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+}
+
+func describeReplayError(err error) string {
+	var errValidation *entity.ErrValidation
+	if errors.As(err, &errValidation) {
+		return fmt.Sprintf("%s %v", errValidation.Error(), errValidation.Errors)
+	}
+	return err.Error()
+}
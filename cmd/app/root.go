@@ -17,30 +17,54 @@ var rootCmd = &cobra.Command{
 	Use:   "grpc-server",
 	Short: "This command to run grpc server",
 	Run: func(cmd *cobra.Command, args []string) {
-		config := config.New()
+		config := loadConfig(cmd)
 		fmt.Println("app running")
 		app, err := app.NewApp(config)
 		if err != nil {
 			log.Fatal(err)
 		}
 
+		runErr := make(chan error, 1)
 		go func() {
-			if err := app.Run(); err != nil {
-				app.Logger.Error("error while app run", zap.Error(err))
-			}
+			runErr <- app.Run()
 		}()
 
 		sigs := make(chan os.Signal, 1)
 		signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
-		<-sigs
 
-		app.Logger.Info("user service stops")
+		select {
+		case <-sigs:
+		case err = <-runErr:
+			if err != nil {
+				app.Logger.Error("error while app run", zap.Error(err))
+			}
+		}
+
+		app.Logger.Info("job service stops")
 
 		// stop app
 		app.Stop()
+
+		if err != nil {
+			os.Exit(1)
+		}
 	},
 }
 
+func init() {
+	config.RegisterFlags(rootCmd.PersistentFlags())
+}
+
+// loadConfig layers the configuration file, environment and the flags of cmd
+// over the defaults and exits reporting every problem on invalid values
+func loadConfig(cmd *cobra.Command) *config.Config {
+	config, err := config.Load(cmd.Flags())
+	if err != nil {
+		log.Fatal(err)
+	}
+	return config
+}
+
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "error while executing CLI '%s", err)
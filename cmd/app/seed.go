@@ -0,0 +1,68 @@
+package app
+
+import (
+	"fifth_exam/job_service/internal/pkg/app"
+	"fifth_exam/job_service/internal/pkg/postgres"
+	"fifth_exam/job_service/internal/pkg/seed"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+var seedCmd = &cobra.Command{
+	Use:   "seed",
+	Short: "Insert generated clients and jobs for development",
+	Long: `Example :
+		go run cmd/main.go seed
+		go run cmd/main.go seed --clients 1000 --jobs 5000 --seed 42 --truncate
+
+	The same --seed always generates the same rows and rows that already exist
+	are skipped. Seeding a production environment requires --force.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		config := loadConfig(cmd)
+
+		flags := cmd.Flags()
+		clients, _ := flags.GetInt("clients")
+		jobs, _ := flags.GetInt("jobs")
+		seedValue, _ := flags.GetInt64("seed")
+		truncate, _ := flags.GetBool("truncate")
+		force, _ := flags.GetBool("force")
+
+		if config.Environment == app.EnvironmentProduction && !force {
+			log.Fatal("refusing to seed a production environment without --force")
+		}
+		if clients < 0 || jobs < 0 {
+			log.Fatal("--clients and --jobs must not be negative")
+		}
+		if jobs > 0 && clients == 0 {
+			log.Fatal("jobs need owners, --clients must be positive")
+		}
+
+		db, err := postgres.New(config)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer db.Close()
+
+		ctx, cancel := signalContext()
+		defer cancel()
+
+		insertedClients, insertedJobs, err := seed.Insert(ctx, db, seed.Generate(seedValue, clients, jobs), truncate)
+		if err != nil {
+			log.Fatalf("seed: %s", err)
+		}
+		fmt.Printf("inserted %d clients and %d jobs\n", insertedClients, insertedJobs)
+	},
+}
+
+func init() {
+	seedCmd.Flags().Int("clients", 10, "number of clients to generate")
+	seedCmd.Flags().Int("jobs", 10, "number of jobs to generate")
+	seedCmd.Flags().Int64("seed", 1, "seed of the generator")
+	seedCmd.Flags().Bool("truncate", false, "empty the clients and jobs tables first")
+	seedCmd.Flags().Bool("force", false, "allow seeding a production environment")
+
+	rootCmd.AddCommand(seedCmd)
+}
@@ -1,11 +1,10 @@
 // Code generated by protoc-gen-gogo. DO NOT EDIT.
 // source: client_service/client.proto
 
-package job
+package client
 
 import (
 	context "context"
-	encoding_binary "encoding/binary"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
 	empty "github.com/golang/protobuf/ptypes/empty"
@@ -29,11 +28,12 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type Client struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
-	Username             string   `protobuf:"bytes,2,opt,name=username,proto3" json:"username"`
-	Email                string   `protobuf:"bytes,3,opt,name=email,proto3" json:"email"`
-	Phone                string   `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone"`
-	Address              float32  `protobuf:"fixed32,5,opt,name=address,proto3" json:"address"`
+	Id       string `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username"`
+	Email    string `protobuf:"bytes,3,opt,name=email,proto3" json:"email"`
+	Phone    string `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone"`
+	Address  string `protobuf:"bytes,5,opt,name=address,proto3" json:"address"`
+	// is_active is ignored by Update, it is changed by Activate and Deactivate
 	IsActive             bool     `protobuf:"varint,6,opt,name=is_active,json=isActive,proto3" json:"is_active"`
 	IsDeleted            bool     `protobuf:"varint,7,opt,name=is_deleted,json=isDeleted,proto3" json:"is_deleted"`
 	CreatedAt            string   `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at"`
@@ -105,11 +105,11 @@ func (m *Client) GetPhone() string {
 	return ""
 }
 
-func (m *Client) GetAddress() float32 {
+func (m *Client) GetAddress() string {
 	if m != nil {
 		return m.Address
 	}
-	return 0
+	return ""
 }
 
 func (m *Client) GetIsActive() bool {
@@ -337,48 +337,48 @@ func (m *Clients) GetClients() []*Client {
 }
 
 func init() {
-	proto.RegisterType((*Client)(nil), "job.Client")
-	proto.RegisterType((*ClientRequest)(nil), "job.ClientRequest")
-	proto.RegisterType((*GetListFilter)(nil), "job.GetListFilter")
-	proto.RegisterType((*Clients)(nil), "job.Clients")
+	proto.RegisterType((*Client)(nil), "client.Client")
+	proto.RegisterType((*ClientRequest)(nil), "client.ClientRequest")
+	proto.RegisterType((*GetListFilter)(nil), "client.GetListFilter")
+	proto.RegisterType((*Clients)(nil), "client.Clients")
 }
 
 func init() { proto.RegisterFile("client_service/client.proto", fileDescriptor_f4b17f987904e2ea) }
 
 var fileDescriptor_f4b17f987904e2ea = []byte{
-	// 500 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0x41, 0x6b, 0xd4, 0x40,
-	0x14, 0xc7, 0x37, 0x49, 0x9b, 0x6c, 0x5e, 0x6d, 0x95, 0x41, 0xca, 0xb8, 0x8b, 0xcb, 0x12, 0x2a,
-	0xe6, 0x62, 0x16, 0x2a, 0x78, 0xf1, 0xb4, 0xad, 0xb6, 0x20, 0x9e, 0x22, 0x9e, 0x43, 0x92, 0x79,
-	0xdd, 0x4e, 0xc9, 0x66, 0x62, 0x66, 0xb2, 0xb0, 0x9f, 0x43, 0x04, 0x3f, 0x92, 0x47, 0x3f, 0x82,
-	0xac, 0x27, 0xbf, 0x85, 0x64, 0x66, 0x52, 0x56, 0x11, 0x6f, 0xf9, 0xfd, 0xff, 0xf3, 0xde, 0x64,
-	0xfe, 0xef, 0xc1, 0xb4, 0xac, 0x38, 0xd6, 0x2a, 0x93, 0xd8, 0x6e, 0x78, 0x89, 0x0b, 0x83, 0x49,
-	0xd3, 0x0a, 0x25, 0x88, 0x77, 0x27, 0x8a, 0xc9, 0x74, 0x25, 0xc4, 0xaa, 0xc2, 0x85, 0x96, 0x8a,
-	0xee, 0x66, 0x81, 0xeb, 0x46, 0x6d, 0xcd, 0x89, 0xe8, 0xb3, 0x0b, 0xfe, 0xa5, 0x2e, 0x21, 0x27,
-	0xe0, 0x72, 0x46, 0x9d, 0xb9, 0x13, 0x87, 0xa9, 0xcb, 0x19, 0x99, 0xc0, 0xb8, 0x93, 0xd8, 0xd6,
-	0xf9, 0x1a, 0xa9, 0xab, 0xd5, 0x7b, 0x26, 0x8f, 0xe1, 0x10, 0xd7, 0x39, 0xaf, 0xa8, 0xa7, 0x0d,
-	0x03, 0xbd, 0xda, 0xdc, 0x8a, 0x1a, 0xe9, 0x81, 0x51, 0x35, 0x10, 0x0a, 0x41, 0xce, 0x58, 0x8b,
-	0x52, 0xd2, 0xc3, 0xb9, 0x13, 0xbb, 0xe9, 0x80, 0x64, 0x0a, 0x21, 0x97, 0x59, 0x5e, 0x2a, 0xbe,
-	0x41, 0xea, 0xcf, 0x9d, 0x78, 0x9c, 0x8e, 0xb9, 0x5c, 0x6a, 0x26, 0x4f, 0x01, 0xb8, 0xcc, 0x18,
-	0x56, 0xa8, 0x90, 0xd1, 0x40, 0xbb, 0x21, 0x97, 0x6f, 0x8c, 0xd0, 0xdb, 0x65, 0x8b, 0xb9, 0x42,
-	0x96, 0xe5, 0x8a, 0x8e, 0xf5, 0x85, 0xa1, 0x55, 0x96, 0xaa, 0xb7, 0xbb, 0x86, 0x0d, 0x76, 0x68,
-	0x6c, 0xab, 0x18, 0xdb, 0x76, 0xee, 0x6d, 0x30, 0xb6, 0x55, 0x96, 0x2a, 0x7a, 0x0d, 0xc7, 0x26,
-	0x94, 0x14, 0x3f, 0x75, 0x28, 0x55, 0xff, 0xb2, 0x1b, 0x8e, 0xd5, 0x10, 0x8f, 0x81, 0x5e, 0xdd,
-	0xe4, 0x55, 0x37, 0xc4, 0x63, 0x20, 0xfa, 0xe2, 0xc0, 0xf1, 0x35, 0xaa, 0xf7, 0x5c, 0xaa, 0x2b,
-	0x5e, 0x29, 0x6c, 0x09, 0x81, 0x83, 0x26, 0x5f, 0xa1, 0x2e, 0xf6, 0x52, 0xfd, 0xdd, 0xd7, 0x56,
-	0x7c, 0xcd, 0x95, 0xae, 0xf5, 0x52, 0x03, 0xe4, 0x14, 0x7c, 0x89, 0x79, 0x5b, 0xde, 0xda, 0x60,
-	0x2d, 0x91, 0x27, 0x30, 0x16, 0x2d, 0xc3, 0x36, 0x2b, 0xb6, 0x36, 0xdc, 0x40, 0xf3, 0xc5, 0x96,
-	0x3c, 0x87, 0x87, 0xbc, 0x2e, 0xab, 0x8e, 0xe1, 0x7d, 0x58, 0x87, 0x3a, 0xac, 0x13, 0x2b, 0xdb,
-	0xc4, 0xa2, 0x2b, 0x08, 0xcc, 0xa3, 0x64, 0x7f, 0x79, 0x29, 0xba, 0x5a, 0xd9, 0x3f, 0x32, 0x40,
-	0x9e, 0x41, 0x60, 0xb6, 0x47, 0x52, 0x77, 0xee, 0xc5, 0x47, 0xe7, 0x47, 0xc9, 0x9d, 0x28, 0x12,
-	0x9b, 0xc4, 0xe0, 0x9d, 0xff, 0x72, 0x00, 0xde, 0x89, 0xe2, 0x83, 0x59, 0x38, 0x72, 0x06, 0xfe,
-	0xa5, 0x8e, 0x9d, 0xec, 0x1f, 0x9f, 0xec, 0x43, 0x34, 0x22, 0x31, 0x78, 0xd7, 0xa8, 0x08, 0xd9,
-	0xef, 0x68, 0xb2, 0xfd, 0xfb, 0xe4, 0x19, 0xf8, 0x1f, 0xf5, 0x9c, 0xfe, 0xdb, 0xef, 0x15, 0xf8,
-	0xe6, 0x5d, 0xff, 0x6c, 0x79, 0x9a, 0x98, 0x9d, 0x4f, 0x86, 0x9d, 0x4f, 0xde, 0xf6, 0x3b, 0x1f,
-	0x8d, 0xc8, 0x0b, 0x08, 0xec, 0x6c, 0x6c, 0xe1, 0x1f, 0x93, 0x9a, 0x3c, 0xd8, 0x6b, 0x26, 0xa3,
-	0xd1, 0xc5, 0xa3, 0x6f, 0xbb, 0x99, 0xf3, 0x7d, 0x37, 0x73, 0x7e, 0xec, 0x66, 0xce, 0xd7, 0x9f,
-	0xb3, 0x51, 0xe1, 0xeb, 0x96, 0x2f, 0x7f, 0x07, 0x00, 0x00, 0xff, 0xff, 0xcf, 0x10, 0x03, 0x7a,
-	0x78, 0x03, 0x00, 0x00,
+	// 509 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0xd1, 0x6e, 0xd3, 0x30,
+	0x14, 0x86, 0x9b, 0x74, 0x4d, 0xd2, 0x83, 0xd6, 0x21, 0x0b, 0x26, 0xd3, 0x8a, 0xaa, 0xca, 0x0d,
+	0xd5, 0x2e, 0x52, 0x69, 0x13, 0x17, 0x88, 0xab, 0x6e, 0x83, 0x09, 0x89, 0xab, 0x22, 0xae, 0x2b,
+	0x37, 0x3e, 0xeb, 0x2c, 0xa5, 0x4d, 0x88, 0x9d, 0x4a, 0x7d, 0x0e, 0x84, 0xc4, 0x23, 0x71, 0xc9,
+	0x23, 0xa0, 0xf2, 0x08, 0xbc, 0x00, 0x8a, 0x8f, 0x33, 0xb4, 0xc2, 0x24, 0x76, 0x97, 0xef, 0xff,
+	0x7d, 0x4e, 0x7c, 0x7e, 0xdb, 0x30, 0x48, 0x33, 0x85, 0x6b, 0x33, 0xd7, 0x58, 0x6e, 0x54, 0x8a,
+	0x13, 0xc2, 0xa4, 0x28, 0x73, 0x93, 0xb3, 0x80, 0xa8, 0x3f, 0x58, 0xe6, 0xf9, 0x32, 0xc3, 0x89,
+	0x55, 0x17, 0xd5, 0xf5, 0x04, 0x57, 0x85, 0xd9, 0xd2, 0xa2, 0xf8, 0xb3, 0x0f, 0xc1, 0x85, 0x5d,
+	0xc7, 0x7a, 0xe0, 0x2b, 0xc9, 0xbd, 0x91, 0x37, 0xee, 0xce, 0x7c, 0x25, 0x59, 0x1f, 0xa2, 0x4a,
+	0x63, 0xb9, 0x16, 0x2b, 0xe4, 0xbe, 0x55, 0x6f, 0x99, 0x3d, 0x81, 0x0e, 0xae, 0x84, 0xca, 0x78,
+	0xdb, 0x1a, 0x04, 0xb5, 0x5a, 0xdc, 0xe4, 0x6b, 0xe4, 0x07, 0xa4, 0x5a, 0x60, 0x1c, 0x42, 0x21,
+	0x65, 0x89, 0x5a, 0xf3, 0x8e, 0xd5, 0x1b, 0x64, 0x03, 0xe8, 0x2a, 0x3d, 0x17, 0xa9, 0x51, 0x1b,
+	0xe4, 0xc1, 0xc8, 0x1b, 0x47, 0xb3, 0x48, 0xe9, 0xa9, 0x65, 0xf6, 0x1c, 0x40, 0xe9, 0xb9, 0xc4,
+	0x0c, 0x0d, 0x4a, 0x1e, 0x5a, 0xb7, 0xab, 0xf4, 0x25, 0x09, 0xb5, 0x9d, 0x96, 0x28, 0x0c, 0xca,
+	0xb9, 0x30, 0x3c, 0xb2, 0x8d, 0xbb, 0x4e, 0x99, 0x9a, 0xda, 0xae, 0x0a, 0xd9, 0xd8, 0x5d, 0xb2,
+	0x9d, 0x42, 0xb6, 0xeb, 0x5c, 0xdb, 0x40, 0xb6, 0x53, 0xa6, 0x26, 0x7e, 0x0d, 0x87, 0x14, 0xca,
+	0x0c, 0x3f, 0x55, 0xa8, 0x4d, 0x3d, 0xd9, 0xb5, 0xc2, 0xac, 0x89, 0x87, 0xa0, 0x56, 0x37, 0x22,
+	0xab, 0x9a, 0x78, 0x08, 0xe2, 0x2f, 0x1e, 0x1c, 0x5e, 0xa1, 0x79, 0xaf, 0xb4, 0x79, 0xab, 0x32,
+	0x83, 0x25, 0x63, 0x70, 0x50, 0x88, 0x25, 0xda, 0xe2, 0xf6, 0xcc, 0x7e, 0xd7, 0xb5, 0x99, 0x5a,
+	0x29, 0x63, 0x6b, 0xdb, 0x33, 0x02, 0x76, 0x0c, 0x81, 0x46, 0x51, 0xa6, 0x37, 0x2e, 0x58, 0x47,
+	0xec, 0x19, 0x44, 0x79, 0x29, 0xb1, 0x9c, 0x2f, 0xb6, 0x2e, 0xdc, 0xd0, 0xf2, 0xf9, 0x96, 0xbd,
+	0x80, 0x23, 0xb5, 0x4e, 0xb3, 0x4a, 0xe2, 0x6d, 0x58, 0x1d, 0x1b, 0x56, 0xcf, 0xc9, 0x2e, 0xb1,
+	0xf8, 0x1d, 0x84, 0x34, 0x94, 0xae, 0x7f, 0x9e, 0xe6, 0xd5, 0xda, 0xb8, 0x1d, 0x11, 0xb0, 0x31,
+	0x84, 0x74, 0x65, 0x34, 0xf7, 0x47, 0xed, 0xf1, 0xa3, 0xd3, 0x5e, 0x42, 0x9c, 0xb8, 0x30, 0x1a,
+	0xfb, 0xf4, 0x97, 0xdf, 0x04, 0xf4, 0x81, 0x6e, 0x1e, 0x3b, 0x81, 0xe0, 0xc2, 0x86, 0xcf, 0xf6,
+	0x8a, 0xfa, 0x7b, 0x1c, 0xb7, 0x58, 0x02, 0xed, 0x2b, 0x34, 0xec, 0xe9, 0x5e, 0x77, 0x8a, 0xfa,
+	0x1f, 0xeb, 0x4f, 0x20, 0xf8, 0x58, 0xc8, 0xff, 0xeb, 0xfd, 0x0a, 0x02, 0x9a, 0xf7, 0xbe, 0xf6,
+	0xc7, 0x09, 0x3d, 0x87, 0xa4, 0x79, 0x0e, 0xc9, 0x9b, 0xfa, 0x39, 0xc4, 0x2d, 0x76, 0x06, 0xa1,
+	0x3b, 0xb6, 0x3f, 0xb5, 0x77, 0xce, 0xb1, 0x7f, 0x74, 0xb7, 0xa5, 0xb6, 0x45, 0x91, 0xbd, 0xaf,
+	0xe2, 0xfe, 0x3f, 0xfe, 0xbd, 0xc9, 0x97, 0x00, 0x97, 0x28, 0x1e, 0x5a, 0x76, 0xfe, 0xf8, 0xdb,
+	0x6e, 0xe8, 0x7d, 0xdf, 0x0d, 0xbd, 0x1f, 0xbb, 0xa1, 0xf7, 0xf5, 0xe7, 0xb0, 0xb5, 0x08, 0xec,
+	0x10, 0x67, 0xbf, 0x07, 0x00, 0x9b, 0x0b, 0xf4, 0xa3, 0x08, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -389,216 +389,288 @@ var _ grpc.ClientConn
 // is compatible with the grpc package it is being compiled against.
 const _ = grpc.SupportPackageIsVersion4
 
-// JobServiceClient is the client API for JobService service.
+// ClientServiceClient is the client API for ClientService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type JobServiceClient interface {
+type ClientServiceClient interface {
 	Create(ctx context.Context, in *Client, opts ...grpc.CallOption) (*Client, error)
 	Get(ctx context.Context, in *ClientRequest, opts ...grpc.CallOption) (*Client, error)
 	Update(ctx context.Context, in *Client, opts ...grpc.CallOption) (*Client, error)
 	Delete(ctx context.Context, in *ClientRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	GetList(ctx context.Context, in *GetListFilter, opts ...grpc.CallOption) (*Clients, error)
+	Activate(ctx context.Context, in *ClientRequest, opts ...grpc.CallOption) (*Client, error)
+	Deactivate(ctx context.Context, in *ClientRequest, opts ...grpc.CallOption) (*Client, error)
 }
 
-type jobServiceClient struct {
+type clientServiceClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewJobServiceClient(cc *grpc.ClientConn) JobServiceClient {
-	return &jobServiceClient{cc}
+func NewClientServiceClient(cc *grpc.ClientConn) ClientServiceClient {
+	return &clientServiceClient{cc}
 }
 
-func (c *jobServiceClient) Create(ctx context.Context, in *Client, opts ...grpc.CallOption) (*Client, error) {
+func (c *clientServiceClient) Create(ctx context.Context, in *Client, opts ...grpc.CallOption) (*Client, error) {
 	out := new(Client)
-	err := c.cc.Invoke(ctx, "/job.JobService/Create", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/client.ClientService/Create", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *jobServiceClient) Get(ctx context.Context, in *ClientRequest, opts ...grpc.CallOption) (*Client, error) {
+func (c *clientServiceClient) Get(ctx context.Context, in *ClientRequest, opts ...grpc.CallOption) (*Client, error) {
 	out := new(Client)
-	err := c.cc.Invoke(ctx, "/job.JobService/Get", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/client.ClientService/Get", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *jobServiceClient) Update(ctx context.Context, in *Client, opts ...grpc.CallOption) (*Client, error) {
+func (c *clientServiceClient) Update(ctx context.Context, in *Client, opts ...grpc.CallOption) (*Client, error) {
 	out := new(Client)
-	err := c.cc.Invoke(ctx, "/job.JobService/Update", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/client.ClientService/Update", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *jobServiceClient) Delete(ctx context.Context, in *ClientRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+func (c *clientServiceClient) Delete(ctx context.Context, in *ClientRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/job.JobService/Delete", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/client.ClientService/Delete", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *jobServiceClient) GetList(ctx context.Context, in *GetListFilter, opts ...grpc.CallOption) (*Clients, error) {
+func (c *clientServiceClient) GetList(ctx context.Context, in *GetListFilter, opts ...grpc.CallOption) (*Clients, error) {
 	out := new(Clients)
-	err := c.cc.Invoke(ctx, "/job.JobService/GetList", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/client.ClientService/GetList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientServiceClient) Activate(ctx context.Context, in *ClientRequest, opts ...grpc.CallOption) (*Client, error) {
+	out := new(Client)
+	err := c.cc.Invoke(ctx, "/client.ClientService/Activate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientServiceClient) Deactivate(ctx context.Context, in *ClientRequest, opts ...grpc.CallOption) (*Client, error) {
+	out := new(Client)
+	err := c.cc.Invoke(ctx, "/client.ClientService/Deactivate", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// JobServiceServer is the server API for JobService service.
-type JobServiceServer interface {
+// ClientServiceServer is the server API for ClientService service.
+type ClientServiceServer interface {
 	Create(context.Context, *Client) (*Client, error)
 	Get(context.Context, *ClientRequest) (*Client, error)
 	Update(context.Context, *Client) (*Client, error)
 	Delete(context.Context, *ClientRequest) (*empty.Empty, error)
 	GetList(context.Context, *GetListFilter) (*Clients, error)
+	Activate(context.Context, *ClientRequest) (*Client, error)
+	Deactivate(context.Context, *ClientRequest) (*Client, error)
 }
 
-// UnimplementedJobServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedJobServiceServer struct {
+// UnimplementedClientServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedClientServiceServer struct {
 }
 
-func (*UnimplementedJobServiceServer) Create(ctx context.Context, req *Client) (*Client, error) {
+func (*UnimplementedClientServiceServer) Create(ctx context.Context, req *Client) (*Client, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
 }
-func (*UnimplementedJobServiceServer) Get(ctx context.Context, req *ClientRequest) (*Client, error) {
+func (*UnimplementedClientServiceServer) Get(ctx context.Context, req *ClientRequest) (*Client, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
-func (*UnimplementedJobServiceServer) Update(ctx context.Context, req *Client) (*Client, error) {
+func (*UnimplementedClientServiceServer) Update(ctx context.Context, req *Client) (*Client, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
 }
-func (*UnimplementedJobServiceServer) Delete(ctx context.Context, req *ClientRequest) (*empty.Empty, error) {
+func (*UnimplementedClientServiceServer) Delete(ctx context.Context, req *ClientRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
-func (*UnimplementedJobServiceServer) GetList(ctx context.Context, req *GetListFilter) (*Clients, error) {
+func (*UnimplementedClientServiceServer) GetList(ctx context.Context, req *GetListFilter) (*Clients, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetList not implemented")
 }
+func (*UnimplementedClientServiceServer) Activate(ctx context.Context, req *ClientRequest) (*Client, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Activate not implemented")
+}
+func (*UnimplementedClientServiceServer) Deactivate(ctx context.Context, req *ClientRequest) (*Client, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Deactivate not implemented")
+}
 
-func RegisterJobServiceServer(s *grpc.Server, srv JobServiceServer) {
-	s.RegisterService(&_JobService_serviceDesc, srv)
+func RegisterClientServiceServer(s *grpc.Server, srv ClientServiceServer) {
+	s.RegisterService(&_ClientService_serviceDesc, srv)
 }
 
-func _JobService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ClientService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Client)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(JobServiceServer).Create(ctx, in)
+		return srv.(ClientServiceServer).Create(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/job.JobService/Create",
+		FullMethod: "/client.ClientService/Create",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(JobServiceServer).Create(ctx, req.(*Client))
+		return srv.(ClientServiceServer).Create(ctx, req.(*Client))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _JobService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ClientService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ClientRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(JobServiceServer).Get(ctx, in)
+		return srv.(ClientServiceServer).Get(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/job.JobService/Get",
+		FullMethod: "/client.ClientService/Get",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(JobServiceServer).Get(ctx, req.(*ClientRequest))
+		return srv.(ClientServiceServer).Get(ctx, req.(*ClientRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _JobService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ClientService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Client)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(JobServiceServer).Update(ctx, in)
+		return srv.(ClientServiceServer).Update(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/job.JobService/Update",
+		FullMethod: "/client.ClientService/Update",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(JobServiceServer).Update(ctx, req.(*Client))
+		return srv.(ClientServiceServer).Update(ctx, req.(*Client))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _JobService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ClientService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ClientRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(JobServiceServer).Delete(ctx, in)
+		return srv.(ClientServiceServer).Delete(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/job.JobService/Delete",
+		FullMethod: "/client.ClientService/Delete",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(JobServiceServer).Delete(ctx, req.(*ClientRequest))
+		return srv.(ClientServiceServer).Delete(ctx, req.(*ClientRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _JobService_GetList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ClientService_GetList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetListFilter)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(JobServiceServer).GetList(ctx, in)
+		return srv.(ClientServiceServer).GetList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/client.ClientService/GetList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServiceServer).GetList(ctx, req.(*GetListFilter))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientService_Activate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServiceServer).Activate(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/job.JobService/GetList",
+		FullMethod: "/client.ClientService/Activate",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(JobServiceServer).GetList(ctx, req.(*GetListFilter))
+		return srv.(ClientServiceServer).Activate(ctx, req.(*ClientRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _JobService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "job.JobService",
-	HandlerType: (*JobServiceServer)(nil),
+func _ClientService_Deactivate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServiceServer).Deactivate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/client.ClientService/Deactivate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServiceServer).Deactivate(ctx, req.(*ClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ClientService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "client.ClientService",
+	HandlerType: (*ClientServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
 			MethodName: "Create",
-			Handler:    _JobService_Create_Handler,
+			Handler:    _ClientService_Create_Handler,
 		},
 		{
 			MethodName: "Get",
-			Handler:    _JobService_Get_Handler,
+			Handler:    _ClientService_Get_Handler,
 		},
 		{
 			MethodName: "Update",
-			Handler:    _JobService_Update_Handler,
+			Handler:    _ClientService_Update_Handler,
 		},
 		{
 			MethodName: "Delete",
-			Handler:    _JobService_Delete_Handler,
+			Handler:    _ClientService_Delete_Handler,
 		},
 		{
 			MethodName: "GetList",
-			Handler:    _JobService_GetList_Handler,
+			Handler:    _ClientService_GetList_Handler,
+		},
+		{
+			MethodName: "Activate",
+			Handler:    _ClientService_Activate_Handler,
+		},
+		{
+			MethodName: "Deactivate",
+			Handler:    _ClientService_Deactivate_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -670,11 +742,12 @@ func (m *Client) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x30
 	}
-	if m.Address != 0 {
-		i -= 4
-		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.Address))))
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintClient(dAtA, i, uint64(len(m.Address)))
 		i--
-		dAtA[i] = 0x2d
+		dAtA[i] = 0x2a
 	}
 	if len(m.Phone) > 0 {
 		i -= len(m.Phone)
@@ -888,8 +961,9 @@ func (m *Client) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovClient(uint64(l))
 	}
-	if m.Address != 0 {
-		n += 5
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovClient(uint64(l))
 	}
 	if m.IsActive {
 		n += 2
@@ -1149,16 +1223,37 @@ func (m *Client) Unmarshal(dAtA []byte) error {
 			m.Phone = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 5:
-			if wireType != 5 {
+			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
 			}
-			var v uint32
-			if (iNdEx + 4) > l {
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClient
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthClient
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClient
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
-			iNdEx += 4
-			m.Address = float32(math.Float32frombits(v))
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 6:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field IsActive", wireType)
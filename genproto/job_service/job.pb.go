@@ -29,16 +29,19 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type Job struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
-	Title                string   `protobuf:"bytes,2,opt,name=title,proto3" json:"title"`
-	Description          string   `protobuf:"bytes,3,opt,name=description,proto3" json:"description"`
-	OwnerId              string   `protobuf:"bytes,4,opt,name=owner_id,json=ownerId,proto3" json:"owner_id"`
-	Price                float32  `protobuf:"fixed32,5,opt,name=price,proto3" json:"price"`
-	FromDate             string   `protobuf:"bytes,6,opt,name=from_date,json=fromDate,proto3" json:"from_date"`
-	ToDate               string   `protobuf:"bytes,7,opt,name=to_date,json=toDate,proto3" json:"to_date"`
-	CreatedAt            string   `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at"`
-	UpdatedAt            string   `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at"`
-	DeletedAt            string   `protobuf:"bytes,10,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at"`
+	Id          string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
+	Title       string  `protobuf:"bytes,2,opt,name=title,proto3" json:"title"`
+	Description string  `protobuf:"bytes,3,opt,name=description,proto3" json:"description"`
+	OwnerId     string  `protobuf:"bytes,4,opt,name=owner_id,json=ownerId,proto3" json:"owner_id"`
+	Price       float32 `protobuf:"fixed32,5,opt,name=price,proto3" json:"price"`
+	FromDate    string  `protobuf:"bytes,6,opt,name=from_date,json=fromDate,proto3" json:"from_date"`
+	ToDate      string  `protobuf:"bytes,7,opt,name=to_date,json=toDate,proto3" json:"to_date"`
+	CreatedAt   string  `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at"`
+	UpdatedAt   string  `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at"`
+	DeletedAt   string  `protobuf:"bytes,10,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at"`
+	// open, in_progress, finished or cancelled
+	Status               string   `protobuf:"bytes,11,opt,name=status,proto3" json:"status"`
+	CancelledAt          string   `protobuf:"bytes,12,opt,name=cancelled_at,json=cancelledAt,proto3" json:"cancelled_at"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -147,6 +150,20 @@ func (m *Job) GetDeletedAt() string {
 	return ""
 }
 
+func (m *Job) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Job) GetCancelledAt() string {
+	if m != nil {
+		return m.CancelledAt
+	}
+	return ""
+}
+
 type JobRequest struct {
 	Field                string   `protobuf:"bytes,1,opt,name=field,proto3" json:"field"`
 	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value"`
@@ -281,6 +298,85 @@ func (m *GetListFilter) GetIncludeDeleted() bool {
 	return false
 }
 
+type ListJobsByOwnerRequest struct {
+	OwnerId              string   `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id"`
+	Page                 int64    `protobuf:"varint,2,opt,name=page,proto3" json:"page"`
+	Limit                int64    `protobuf:"varint,3,opt,name=limit,proto3" json:"limit"`
+	OrderBy              string   `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by"`
+	IncludeDeleted       bool     `protobuf:"varint,5,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListJobsByOwnerRequest) Reset()         { *m = ListJobsByOwnerRequest{} }
+func (m *ListJobsByOwnerRequest) String() string { return proto.CompactTextString(m) }
+func (*ListJobsByOwnerRequest) ProtoMessage()    {}
+func (*ListJobsByOwnerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d1508a41534d64be, []int{3}
+}
+func (m *ListJobsByOwnerRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListJobsByOwnerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListJobsByOwnerRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListJobsByOwnerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJobsByOwnerRequest.Merge(m, src)
+}
+func (m *ListJobsByOwnerRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListJobsByOwnerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJobsByOwnerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJobsByOwnerRequest proto.InternalMessageInfo
+
+func (m *ListJobsByOwnerRequest) GetOwnerId() string {
+	if m != nil {
+		return m.OwnerId
+	}
+	return ""
+}
+
+func (m *ListJobsByOwnerRequest) GetPage() int64 {
+	if m != nil {
+		return m.Page
+	}
+	return 0
+}
+
+func (m *ListJobsByOwnerRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListJobsByOwnerRequest) GetOrderBy() string {
+	if m != nil {
+		return m.OrderBy
+	}
+	return ""
+}
+
+func (m *ListJobsByOwnerRequest) GetIncludeDeleted() bool {
+	if m != nil {
+		return m.IncludeDeleted
+	}
+	return false
+}
+
 type Jobs struct {
 	Count                int64    `protobuf:"varint,1,opt,name=count,proto3" json:"count"`
 	Jobs                 []*Job   `protobuf:"bytes,2,rep,name=jobs,proto3" json:"jobs"`
@@ -293,7 +389,7 @@ func (m *Jobs) Reset()         { *m = Jobs{} }
 func (m *Jobs) String() string { return proto.CompactTextString(m) }
 func (*Jobs) ProtoMessage()    {}
 func (*Jobs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d1508a41534d64be, []int{3}
+	return fileDescriptor_d1508a41534d64be, []int{4}
 }
 func (m *Jobs) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -340,44 +436,50 @@ func init() {
 	proto.RegisterType((*Job)(nil), "job.Job")
 	proto.RegisterType((*JobRequest)(nil), "job.JobRequest")
 	proto.RegisterType((*GetListFilter)(nil), "job.GetListFilter")
+	proto.RegisterType((*ListJobsByOwnerRequest)(nil), "job.ListJobsByOwnerRequest")
 	proto.RegisterType((*Jobs)(nil), "job.Jobs")
 }
 
 func init() { proto.RegisterFile("job_service/job.proto", fileDescriptor_d1508a41534d64be) }
 
 var fileDescriptor_d1508a41534d64be = []byte{
-	// 494 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xcf, 0x6e, 0xd3, 0x40,
-	0x10, 0xc6, 0x63, 0x3b, 0x75, 0x92, 0xa9, 0x68, 0xd1, 0x0a, 0x8a, 0x69, 0xc1, 0x8a, 0x7c, 0x21,
-	0xe2, 0x90, 0x48, 0xed, 0x05, 0x71, 0x6b, 0x29, 0x54, 0x44, 0x9c, 0x8c, 0x38, 0x5b, 0xfe, 0x33,
-	0x09, 0x1b, 0x39, 0x59, 0xb3, 0x1e, 0x17, 0xe5, 0x31, 0x38, 0x20, 0xf1, 0x48, 0x1c, 0xe1, 0x0d,
-	0x50, 0x78, 0x11, 0xe4, 0xd9, 0x8d, 0x45, 0x85, 0xb8, 0xf9, 0xfb, 0x7e, 0x33, 0xe3, 0xd1, 0xb7,
-	0x03, 0x0f, 0x57, 0x2a, 0x4b, 0x6a, 0xd4, 0xb7, 0x32, 0xc7, 0xd9, 0x4a, 0x65, 0xd3, 0x4a, 0x2b,
-	0x52, 0xc2, 0x5b, 0xa9, 0xec, 0xf4, 0x6c, 0xa9, 0xd4, 0xb2, 0xc4, 0x19, 0x5b, 0x59, 0xb3, 0x98,
-	0xe1, 0xba, 0xa2, 0xad, 0xa9, 0x88, 0xbe, 0xb8, 0xe0, 0xcd, 0x55, 0x26, 0x8e, 0xc0, 0x95, 0x45,
-	0xe0, 0x8c, 0x9d, 0xc9, 0x28, 0x76, 0x65, 0x21, 0x1e, 0xc0, 0x01, 0x49, 0x2a, 0x31, 0x70, 0xd9,
-	0x32, 0x42, 0x8c, 0xe1, 0xb0, 0xc0, 0x3a, 0xd7, 0xb2, 0x22, 0xa9, 0x36, 0x81, 0xc7, 0xec, 0x6f,
-	0x4b, 0x3c, 0x86, 0xa1, 0xfa, 0xbc, 0x41, 0x9d, 0xc8, 0x22, 0xe8, 0x33, 0x1e, 0xb0, 0x7e, 0xcb,
-	0x23, 0x2b, 0x2d, 0x73, 0x0c, 0x0e, 0xc6, 0xce, 0xc4, 0x8d, 0x8d, 0x10, 0x67, 0x30, 0x5a, 0x68,
-	0xb5, 0x4e, 0x8a, 0x94, 0x30, 0xf0, 0xb9, 0x63, 0xd8, 0x1a, 0xd7, 0x29, 0xa1, 0x78, 0x04, 0x03,
-	0x52, 0x06, 0x0d, 0x18, 0xf9, 0xa4, 0x18, 0x3c, 0x05, 0xc8, 0x35, 0xa6, 0x84, 0x45, 0x92, 0x52,
-	0x30, 0x64, 0x36, 0xb2, 0xce, 0x25, 0xb5, 0xb8, 0xa9, 0x8a, 0x3d, 0x1e, 0x19, 0x6c, 0x1d, 0x83,
-	0x0b, 0x2c, 0xd1, 0x62, 0x30, 0xd8, 0x3a, 0x97, 0x14, 0xbd, 0x00, 0x98, 0xab, 0x2c, 0xc6, 0x4f,
-	0x0d, 0xd6, 0xd4, 0xae, 0xbd, 0x90, 0x58, 0xee, 0xc3, 0x31, 0xa2, 0x75, 0x6f, 0xd3, 0xb2, 0xe9,
-	0xf2, 0x61, 0x11, 0x7d, 0x75, 0xe0, 0xde, 0x0d, 0xd2, 0x3b, 0x59, 0xd3, 0x1b, 0x59, 0x12, 0x6a,
-	0x21, 0xa0, 0x5f, 0xa5, 0x4b, 0xe4, 0x66, 0x2f, 0xe6, 0xef, 0xb6, 0xb7, 0x94, 0x6b, 0x49, 0xdc,
-	0xeb, 0xc5, 0x46, 0x88, 0x13, 0xf0, 0x6b, 0x4c, 0x75, 0xfe, 0xd1, 0xc6, 0x6a, 0x15, 0x27, 0xaa,
-	0x0b, 0xd4, 0x49, 0xb6, 0xed, 0x12, 0x6d, 0xf5, 0xd5, 0x56, 0x3c, 0x83, 0x63, 0xb9, 0xc9, 0xcb,
-	0xa6, 0xc0, 0xc4, 0x6e, 0xcf, 0xd9, 0x0e, 0xe3, 0x23, 0x6b, 0x5f, 0x1b, 0x37, 0x7a, 0x09, 0xfd,
-	0xb9, 0xca, 0xea, 0xf6, 0xcf, 0xb9, 0x6a, 0x36, 0x64, 0xd7, 0x31, 0x42, 0x3c, 0x81, 0xfe, 0x4a,
-	0x65, 0x75, 0xe0, 0x8e, 0xbd, 0xc9, 0xe1, 0xf9, 0x70, 0xda, 0xde, 0x4f, 0x1b, 0x00, 0xbb, 0xe7,
-	0x3f, 0x1d, 0x8e, 0xe3, 0xbd, 0x39, 0x2e, 0x11, 0x82, 0xff, 0x8a, 0x73, 0x16, 0x5d, 0xe1, 0x69,
-	0xf7, 0x15, 0xf5, 0x44, 0x04, 0xde, 0x0d, 0x92, 0x38, 0xee, 0xa6, 0x98, 0x18, 0xef, 0xd4, 0x84,
-	0xe0, 0x7f, 0xe0, 0xc7, 0xf8, 0xcf, 0x8c, 0x0b, 0xf0, 0xcd, 0xe6, 0xff, 0x8e, 0x39, 0x99, 0x9a,
-	0x6b, 0x9e, 0xee, 0xaf, 0x79, 0xfa, 0xba, 0xbd, 0xe6, 0xa8, 0x27, 0x9e, 0xc3, 0xc0, 0x46, 0x2f,
-	0x04, 0x77, 0xdd, 0x79, 0x88, 0xd3, 0xd1, 0x7e, 0x52, 0x1d, 0xf5, 0xae, 0xee, 0x7f, 0xdf, 0x85,
-	0xce, 0x8f, 0x5d, 0xe8, 0xfc, 0xda, 0x85, 0xce, 0xb7, 0xdf, 0x61, 0x2f, 0xf3, 0x79, 0xde, 0xc5,
-	0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xcb, 0xb2, 0xf9, 0x19, 0x49, 0x03, 0x00, 0x00,
+	// 570 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x53, 0x4f, 0x6f, 0xd3, 0x4e,
+	0x10, 0x8d, 0xed, 0xd4, 0x49, 0xa6, 0xfd, 0xb5, 0x3f, 0xad, 0xa0, 0x98, 0x06, 0xac, 0xe0, 0x0b,
+	0x11, 0x87, 0x44, 0x6a, 0x2f, 0x08, 0x4e, 0x09, 0x85, 0x8a, 0x08, 0x09, 0xc9, 0x88, 0xb3, 0xe5,
+	0x3f, 0x93, 0xb0, 0x91, 0x93, 0x35, 0xf6, 0xba, 0x28, 0x5f, 0x82, 0x1b, 0x12, 0x57, 0xbe, 0x0d,
+	0x07, 0x0e, 0x7c, 0x04, 0x14, 0xbe, 0x08, 0xda, 0x59, 0xdb, 0x4a, 0xa0, 0xdc, 0xb8, 0xf9, 0xbd,
+	0xb7, 0x33, 0x7e, 0x3b, 0x6f, 0x07, 0x6e, 0x2f, 0x45, 0x14, 0x14, 0x98, 0x5f, 0xf3, 0x18, 0xc7,
+	0x4b, 0x11, 0x8d, 0xb2, 0x5c, 0x48, 0xc1, 0xac, 0xa5, 0x88, 0xce, 0xfa, 0x0b, 0x21, 0x16, 0x29,
+	0x8e, 0x89, 0x8a, 0xca, 0xf9, 0x18, 0x57, 0x99, 0xdc, 0xe8, 0x13, 0xde, 0x37, 0x13, 0xac, 0x99,
+	0x88, 0xd8, 0x31, 0x98, 0x3c, 0x71, 0x8c, 0x81, 0x31, 0xec, 0xf9, 0x26, 0x4f, 0xd8, 0x2d, 0x38,
+	0x90, 0x5c, 0xa6, 0xe8, 0x98, 0x44, 0x69, 0xc0, 0x06, 0x70, 0x98, 0x60, 0x11, 0xe7, 0x3c, 0x93,
+	0x5c, 0xac, 0x1d, 0x8b, 0xb4, 0x5d, 0x8a, 0xdd, 0x85, 0xae, 0xf8, 0xb0, 0xc6, 0x3c, 0xe0, 0x89,
+	0xd3, 0x26, 0xb9, 0x43, 0xf8, 0x25, 0xb5, 0xcc, 0x72, 0x1e, 0xa3, 0x73, 0x30, 0x30, 0x86, 0xa6,
+	0xaf, 0x01, 0xeb, 0x43, 0x6f, 0x9e, 0x8b, 0x55, 0x90, 0x84, 0x12, 0x1d, 0x9b, 0x2a, 0xba, 0x8a,
+	0xb8, 0x0c, 0x25, 0xb2, 0x3b, 0xd0, 0x91, 0x42, 0x4b, 0x1d, 0x92, 0x6c, 0x29, 0x48, 0xb8, 0x0f,
+	0x10, 0xe7, 0x18, 0x4a, 0x4c, 0x82, 0x50, 0x3a, 0x5d, 0xd2, 0x7a, 0x15, 0x33, 0x91, 0x4a, 0x2e,
+	0xb3, 0xa4, 0x96, 0x7b, 0x5a, 0xae, 0x18, 0x2d, 0x27, 0x98, 0x62, 0x25, 0x83, 0x96, 0x2b, 0x66,
+	0x22, 0xd9, 0x29, 0xd8, 0x85, 0x0c, 0x65, 0x59, 0x38, 0x87, 0xfa, 0xa7, 0x1a, 0xb1, 0x07, 0x70,
+	0x14, 0x87, 0xeb, 0x18, 0xd3, 0x54, 0x17, 0x1e, 0xe9, 0xeb, 0x37, 0xdc, 0x44, 0x7a, 0x8f, 0x01,
+	0x66, 0x22, 0xf2, 0xf1, 0x7d, 0x89, 0x85, 0x54, 0x37, 0x9e, 0x73, 0x4c, 0xeb, 0xb9, 0x6a, 0xa0,
+	0xd8, 0xeb, 0x30, 0x2d, 0x9b, 0xd1, 0x12, 0xf0, 0x3e, 0x19, 0xf0, 0xdf, 0x15, 0xca, 0x57, 0xbc,
+	0x90, 0x2f, 0x78, 0x2a, 0x31, 0x67, 0x0c, 0xda, 0x59, 0xb8, 0x40, 0x2a, 0xb6, 0x7c, 0xfa, 0x56,
+	0xb5, 0x29, 0x5f, 0x71, 0x49, 0xb5, 0x96, 0xaf, 0x01, 0x19, 0xc6, 0x30, 0x8f, 0xdf, 0x55, 0x89,
+	0x54, 0x88, 0xc2, 0xc8, 0x13, 0xcc, 0x83, 0x68, 0xd3, 0x84, 0xa1, 0xf0, 0x74, 0xc3, 0x1e, 0xc2,
+	0x09, 0x5f, 0xc7, 0x69, 0x99, 0x60, 0x50, 0x5d, 0x9c, 0x62, 0xe9, 0xfa, 0xc7, 0x15, 0x7d, 0xa9,
+	0x59, 0xef, 0x8b, 0x01, 0xa7, 0xca, 0xd4, 0x4c, 0x44, 0xc5, 0x74, 0xf3, 0x5a, 0x65, 0x59, 0x5f,
+	0x6f, 0x37, 0x6b, 0x63, 0x3f, 0xeb, 0xda, 0xbb, 0x79, 0x93, 0x77, 0x6b, 0xd7, 0xfb, 0xbf, 0xf0,
+	0xf8, 0x04, 0xda, 0xca, 0x9e, 0xfa, 0x43, 0x2c, 0xca, 0xb5, 0xac, 0x46, 0xa6, 0x01, 0xbb, 0x07,
+	0xed, 0xa5, 0x88, 0x0a, 0xc7, 0x1c, 0x58, 0xc3, 0xc3, 0xf3, 0xee, 0x48, 0xad, 0x87, 0x0a, 0x89,
+	0xd8, 0xf3, 0x8f, 0x26, 0x45, 0xf6, 0x46, 0xef, 0x0e, 0x73, 0xc1, 0x7e, 0x46, 0xcf, 0x88, 0x35,
+	0x07, 0xcf, 0x9a, 0x2f, 0xaf, 0xc5, 0x3c, 0xb0, 0xae, 0x50, 0xb2, 0x93, 0xa6, 0x8b, 0x9e, 0xc5,
+	0xde, 0x19, 0x17, 0xec, 0xb7, 0x59, 0xf2, 0xf7, 0x1e, 0x17, 0x60, 0x6b, 0xe7, 0x7f, 0xb6, 0x39,
+	0x1d, 0xe9, 0x65, 0x1d, 0xd5, 0xcb, 0x3a, 0x7a, 0xae, 0x96, 0xd5, 0x6b, 0xb1, 0x47, 0xd0, 0xa9,
+	0x9e, 0x07, 0x63, 0x54, 0xb5, 0xf7, 0x58, 0xce, 0x7a, 0x75, 0xa7, 0xc2, 0x6b, 0xb1, 0xa7, 0x70,
+	0xf2, 0x5b, 0x64, 0xac, 0x4f, 0xfa, 0xcd, 0x41, 0xee, 0x15, 0x4f, 0xff, 0xff, 0xba, 0x75, 0x8d,
+	0xef, 0x5b, 0xd7, 0xf8, 0xb1, 0x75, 0x8d, 0xcf, 0x3f, 0xdd, 0x56, 0x64, 0x93, 0x99, 0x8b, 0x5f,
+	0x03, 0x00, 0x55, 0x90, 0x65, 0xc3, 0x65, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -397,6 +499,7 @@ type JobServiceClient interface {
 	Update(ctx context.Context, in *Job, opts ...grpc.CallOption) (*Job, error)
 	Delete(ctx context.Context, in *JobRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	GetList(ctx context.Context, in *GetListFilter, opts ...grpc.CallOption) (*Jobs, error)
+	ListJobsByOwner(ctx context.Context, in *ListJobsByOwnerRequest, opts ...grpc.CallOption) (*Jobs, error)
 }
 
 type jobServiceClient struct {
@@ -452,6 +555,15 @@ func (c *jobServiceClient) GetList(ctx context.Context, in *GetListFilter, opts
 	return out, nil
 }
 
+func (c *jobServiceClient) ListJobsByOwner(ctx context.Context, in *ListJobsByOwnerRequest, opts ...grpc.CallOption) (*Jobs, error) {
+	out := new(Jobs)
+	err := c.cc.Invoke(ctx, "/job.JobService/ListJobsByOwner", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // JobServiceServer is the server API for JobService service.
 type JobServiceServer interface {
 	Create(context.Context, *Job) (*Job, error)
@@ -459,6 +571,7 @@ type JobServiceServer interface {
 	Update(context.Context, *Job) (*Job, error)
 	Delete(context.Context, *JobRequest) (*empty.Empty, error)
 	GetList(context.Context, *GetListFilter) (*Jobs, error)
+	ListJobsByOwner(context.Context, *ListJobsByOwnerRequest) (*Jobs, error)
 }
 
 // UnimplementedJobServiceServer can be embedded to have forward compatible implementations.
@@ -480,6 +593,9 @@ func (*UnimplementedJobServiceServer) Delete(ctx context.Context, req *JobReques
 func (*UnimplementedJobServiceServer) GetList(ctx context.Context, req *GetListFilter) (*Jobs, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetList not implemented")
 }
+func (*UnimplementedJobServiceServer) ListJobsByOwner(ctx context.Context, req *ListJobsByOwnerRequest) (*Jobs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobsByOwner not implemented")
+}
 
 func RegisterJobServiceServer(s *grpc.Server, srv JobServiceServer) {
 	s.RegisterService(&_JobService_serviceDesc, srv)
@@ -575,6 +691,24 @@ func _JobService_GetList_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _JobService_ListJobsByOwner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobsByOwnerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).ListJobsByOwner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/job.JobService/ListJobsByOwner",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).ListJobsByOwner(ctx, req.(*ListJobsByOwnerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _JobService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "job.JobService",
 	HandlerType: (*JobServiceServer)(nil),
@@ -599,6 +733,10 @@ var _JobService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetList",
 			Handler:    _JobService_GetList_Handler,
 		},
+		{
+			MethodName: "ListJobsByOwner",
+			Handler:    _JobService_ListJobsByOwner_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "job_service/job.proto",
@@ -628,6 +766,20 @@ func (m *Job) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.CancelledAt) > 0 {
+		i -= len(m.CancelledAt)
+		copy(dAtA[i:], m.CancelledAt)
+		i = encodeVarintJob(dAtA, i, uint64(len(m.CancelledAt)))
+		i--
+		dAtA[i] = 0x62
+	}
+	if len(m.Status) > 0 {
+		i -= len(m.Status)
+		copy(dAtA[i:], m.Status)
+		i = encodeVarintJob(dAtA, i, uint64(len(m.Status)))
+		i--
+		dAtA[i] = 0x5a
+	}
 	if len(m.DeletedAt) > 0 {
 		i -= len(m.DeletedAt)
 		copy(dAtA[i:], m.DeletedAt)
@@ -802,6 +954,67 @@ func (m *GetListFilter) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ListJobsByOwnerRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListJobsByOwnerRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListJobsByOwnerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.IncludeDeleted {
+		i--
+		if m.IncludeDeleted {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.OrderBy) > 0 {
+		i -= len(m.OrderBy)
+		copy(dAtA[i:], m.OrderBy)
+		i = encodeVarintJob(dAtA, i, uint64(len(m.OrderBy)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Limit != 0 {
+		i = encodeVarintJob(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Page != 0 {
+		i = encodeVarintJob(dAtA, i, uint64(m.Page))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.OwnerId) > 0 {
+		i -= len(m.OwnerId)
+		copy(dAtA[i:], m.OwnerId)
+		i = encodeVarintJob(dAtA, i, uint64(len(m.OwnerId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Jobs) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -904,6 +1117,14 @@ func (m *Job) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovJob(uint64(l))
 	}
+	l = len(m.Status)
+	if l > 0 {
+		n += 1 + l + sovJob(uint64(l))
+	}
+	l = len(m.CancelledAt)
+	if l > 0 {
+		n += 1 + l + sovJob(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -959,6 +1180,35 @@ func (m *GetListFilter) Size() (n int) {
 	return n
 }
 
+func (m *ListJobsByOwnerRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.OwnerId)
+	if l > 0 {
+		n += 1 + l + sovJob(uint64(l))
+	}
+	if m.Page != 0 {
+		n += 1 + sovJob(uint64(m.Page))
+	}
+	if m.Limit != 0 {
+		n += 1 + sovJob(uint64(m.Limit))
+	}
+	l = len(m.OrderBy)
+	if l > 0 {
+		n += 1 + l + sovJob(uint64(l))
+	}
+	if m.IncludeDeleted {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *Jobs) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1314,6 +1564,70 @@ func (m *Job) Unmarshal(dAtA []byte) error {
 			}
 			m.DeletedAt = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowJob
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthJob
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthJob
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Status = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CancelledAt", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowJob
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthJob
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthJob
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CancelledAt = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipJob(dAtA[iNdEx:])
@@ -1624,6 +1938,179 @@ func (m *GetListFilter) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ListJobsByOwnerRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowJob
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListJobsByOwnerRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListJobsByOwnerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OwnerId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowJob
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthJob
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthJob
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OwnerId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Page", wireType)
+			}
+			m.Page = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowJob
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Page |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowJob
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderBy", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowJob
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthJob
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthJob
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OrderBy = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeDeleted", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowJob
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IncludeDeleted = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipJob(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthJob
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Jobs) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
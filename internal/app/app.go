@@ -1,57 +1,171 @@
 package app
 
 import (
+	"context"
+	"errors"
+	clientpb "fifth_exam/job_service/genproto/client_service"
 	pb "fifth_exam/job_service/genproto/job_service"
+	"fifth_exam/job_service/internal/delivery/grpc/health"
+	"fifth_exam/job_service/internal/delivery/grpc/interceptors"
 	"fifth_exam/job_service/internal/delivery/grpc/server"
 	"fifth_exam/job_service/internal/delivery/grpc/services"
+	"fifth_exam/job_service/internal/delivery/rest"
 	grpc_service_clients "fifth_exam/job_service/internal/infrastructure/grpc_service_client"
 	"fifth_exam/job_service/internal/infrastructure/kafka"
+	"fifth_exam/job_service/internal/infrastructure/repository"
 	"fifth_exam/job_service/internal/infrastructure/repository/postgresql"
+	"fifth_exam/job_service/internal/pkg/admin"
+	"fifth_exam/job_service/internal/pkg/auth"
 	"fifth_exam/job_service/internal/pkg/config"
-	"fifth_exam/job_service/internal/pkg/logger"
+	logpkg "fifth_exam/job_service/internal/pkg/logger"
+	"fifth_exam/job_service/internal/pkg/metrics"
 	"fifth_exam/job_service/internal/pkg/otlp"
 	"fifth_exam/job_service/internal/pkg/postgres"
+	"fifth_exam/job_service/internal/pkg/ratelimit"
 	"fifth_exam/job_service/internal/usecase"
 	"fifth_exam/job_service/internal/usecase/event"
+	"fifth_exam/job_service/migrations"
 	"fmt"
+	"net"
+	"net/http"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
+// otlpFlushTimeout bounds the export of the spans left on shutdown, it is
+// not taken from the shutdown timeout which slow servers may have used up
+const otlpFlushTimeout = 5 * time.Second
+
 type App struct {
 	Config         *config.Config
 	Logger         *zap.Logger
 	DB             *postgres.PostgresDB
 	ServiceClients grpc_service_clients.ServiceClients
 	GrpcServer     *grpc.Server
-	ShutdownOTLP   func() error
+	ShutdownOTLP   func(ctx context.Context) error
 	BrokerConsumer event.BrokerConsumer
+	Metrics        *prometheus.Registry
+	MetricsServer  *http.Server
+	// AdminServer is nil when the admin endpoints are disabled
+	AdminServer *http.Server
+	// GatewayServer and GatewayConn are nil when the gateway is disabled
+	GatewayServer *http.Server
+	GatewayConn   *grpc.ClientConn
+	Health        *health.Checker
+	// LogLevel is changed at runtime by SIGUSR1 and the admin endpoint
+	LogLevel zap.AtomicLevel
+
+	stopLevelSignal func()
 }
 
 func NewApp(cfg *config.Config) (*App, error) {
-	logger, err := logger.New(cfg.LogLevel, cfg.Environment, cfg.APP+".log")
+	logger, logLevel, err := newLogger(cfg, cfg.APP+".log")
 	if err != nil {
 		return nil, err
 	}
 
+	if cfg.DB.AutoMigrate {
+		if err := migrateUp(cfg, logger); err != nil {
+			return nil, err
+		}
+	}
+
 	db, err := postgres.New(cfg)
 	if err != nil {
 		return nil, err
 	}
 
+	// tracing is not required to serve requests, the service runs untraced
+	// when the provider can't be set up
 	shutdownOTLP, err := otlp.InitOTLPProvider(cfg)
 	if err != nil {
-		fmt.Println("initOTLP provider error")
+		logger.Warn("tracing disabled", zap.Error(err))
+		shutdownOTLP = func(context.Context) error { return nil }
+	}
+
+	registry := metrics.NewRegistry()
+	registry.MustRegister(postgres.NewPoolCollector(db))
+
+	authInterceptors, err := newAuthInterceptors(cfg, logger)
+	if err != nil {
 		return nil, err
 	}
-	grpcServer := grpc.NewServer()
+
+	// the access log sees the code of recovered panics and of requests
+	// rejected by the auth and rate limit interceptors
+	var (
+		gatewayToken      string
+		unaryInterceptors []grpc.UnaryServerInterceptor
+	)
+	if cfg.Gateway.Enabled {
+		// the gateway proves with the token, new on every start, that the
+		// client address it forwards can be trusted
+		gatewayToken = uuid.NewString()
+		unaryInterceptors = append(unaryInterceptors, interceptors.Forwarded(gatewayToken))
+	}
+	unaryInterceptors = append(unaryInterceptors, interceptors.RequestID(), interceptors.ContextLogger(logger))
+	if cfg.AccessLog.Enabled {
+		accessLog := interceptors.NewAccessLog(logger, cfg.AccessLog.Payloads, cfg.AccessLog.RedactFields)
+		unaryInterceptors = append(unaryInterceptors, accessLog.UnaryServerInterceptor())
+	}
+	unaryInterceptors = append(unaryInterceptors,
+		interceptors.NewMetrics(registry).UnaryServerInterceptor(),
+		interceptors.Recovery(logger),
+	)
+	unaryInterceptors = append(unaryInterceptors, authInterceptors...)
+	if cfg.RateLimit.Enabled {
+		rateLimit, err := newRateLimitInterceptor(cfg)
+		if err != nil {
+			return nil, err
+		}
+		unaryInterceptors = append(unaryInterceptors, rateLimit)
+	}
+
+	// the stats handler starts the server span of every RPC as a child of the
+	// trace context propagated by the caller
+	grpcServer := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+	)
 	clients, err := grpc_service_clients.New(cfg)
 	if err != nil {
 		return nil, err
 	}
-	brokerConsumer := kafka.NewConsumer(logger)
+	brokerConsumer := kafka.NewConsumer(logger, registry)
+
+	healthChecker := health.NewChecker(logger, cfg.Health.CheckInterval)
+	healthChecker.AddCheck("postgres", db.Ping)
+	healthChecker.AddCheck("kafka", func(ctx context.Context) error {
+		return kafka.Ping(ctx, cfg.Kafka.Address)
+	})
+
+	var (
+		gatewayServer *http.Server
+		gatewayConn   *grpc.ClientConn
+	)
+	if cfg.Gateway.Enabled {
+		if gatewayServer, gatewayConn, err = newGateway(cfg, logger, gatewayToken); err != nil {
+			return nil, err
+		}
+	}
+
+	var adminServer *http.Server
+	if cfg.Admin.Enabled {
+		adminServer = admin.NewServer(cfg.Admin.Port, admin.Options{
+			Token:     cfg.Admin.Token,
+			Config:    cfg,
+			Gatherer:  registry,
+			Level:     logLevel,
+			Consumers: brokerConsumer,
+		})
+	}
 
 	return &App{
 		Config:         cfg,
@@ -61,31 +175,184 @@ func NewApp(cfg *config.Config) (*App, error) {
 		ServiceClients: clients,
 		BrokerConsumer: brokerConsumer,
 		ShutdownOTLP:   shutdownOTLP,
+		Metrics:        registry,
+		MetricsServer:  metrics.NewServer(cfg.Metrics.Port, registry),
+		AdminServer:    adminServer,
+		GatewayServer:  gatewayServer,
+		GatewayConn:    gatewayConn,
+		Health:         healthChecker,
+		LogLevel:       logLevel,
+
+		stopLevelSignal: logpkg.HandleLevelSignal(logLevel, logger),
 	}, nil
 }
 
-func (a *App) Run() error {
-	var (
-		contextTimeout time.Duration
+// newGateway returns the REST/JSON gateway server and its connection to the
+// gRPC server of this process, the connection is established lazily and
+// attaches token to every RPC
+func newGateway(cfg *config.Config, logger *zap.Logger, token string) (*http.Server, *grpc.ClientConn, error) {
+	conn, err := grpc.NewClient(loopback(cfg.RPCPort),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithUnaryInterceptor(interceptors.GatewayToken(token)),
 	)
-	contextTimeout, err := time.ParseDuration(a.Config.Context.Timeout)
 	if err != nil {
-		return fmt.Errorf("error during parse duration for context timeout : %w", err)
+		return nil, nil, fmt.Errorf("gateway: %w", err)
+	}
+
+	return &http.Server{
+		Addr:              cfg.Gateway.Port,
+		Handler:           rest.NewHandler(pb.NewJobServiceClient(conn), logger, cfg.Gateway.CORSOrigins),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       cfg.Context.Timeout,
+		WriteTimeout:      cfg.Context.Timeout + 5*time.Second,
+	}, conn, nil
+}
+
+// loopback returns the address a listener on addr is reached at from this
+// host, e.g. localhost:9090 for :9090
+func loopback(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// newAuthInterceptors verifies the JWT of every RPC but health checks and
+// enforces the RBAC policy, with auth disabled every caller is treated as an
+// admin
+func newAuthInterceptors(cfg *config.Config, logger *zap.Logger) ([]grpc.UnaryServerInterceptor, error) {
+	if !cfg.Auth.Enabled {
+		logger.Warn("authentication is disabled, every caller acts as an admin")
+		return []grpc.UnaryServerInterceptor{
+			interceptors.Anonymous(&auth.Identity{Subject: "anonymous", Roles: []string{auth.RoleAdmin}}),
+		}, nil
 	}
 
+	verifier, err := auth.NewVerifier(auth.Config{
+		HMACSecret:   cfg.Auth.HMACSecret,
+		RSAPublicKey: cfg.Auth.RSAPublicKey,
+		Issuer:       cfg.Auth.Issuer,
+		Audience:     cfg.Auth.Audience,
+		RolesClaim:   cfg.Auth.RolesClaim,
+		Leeway:       cfg.Auth.Leeway,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := auth.NewPolicy(cfg.RBAC.Roles, cfg.RBAC.Methods)
+	if err != nil {
+		return nil, err
+	}
+
+	public := "/" + healthpb.Health_ServiceDesc.ServiceName + "/"
+	return []grpc.UnaryServerInterceptor{
+		interceptors.NewAuth(verifier, public).UnaryServerInterceptor(),
+		interceptors.NewRBAC(policy, public).UnaryServerInterceptor(),
+	}, nil
+}
+
+// newRateLimitInterceptor limits callers by the subject of their token, or by
+// their address when authentication is disabled
+func newRateLimitInterceptor(cfg *config.Config) (grpc.UnaryServerInterceptor, error) {
+	quota, err := ratelimit.ParseQuota(cfg.RateLimit.Default)
+	if err != nil {
+		return nil, fmt.Errorf("rate_limit.default: %w", err)
+	}
+
+	methods := make(map[string]ratelimit.Quota, len(cfg.RateLimit.Methods))
+	for method, values := range cfg.RateLimit.Methods {
+		if methods[method], err = ratelimit.ParseQuota(values); err != nil {
+			return nil, fmt.Errorf("rate_limit.methods %s: %w", method, err)
+		}
+	}
+
+	limiter := ratelimit.NewLimiter(cfg.RateLimit.IdleTimeout)
+	return interceptors.NewRateLimit(limiter, quota, methods, cfg.Auth.Enabled).UnaryServerInterceptor(), nil
+}
+
+// serveAdmin serves the admin endpoints until server is shut down, without
+// a token they are open to whoever reaches the listener
+func serveAdmin(server *http.Server, cfg *config.Config, logger *zap.Logger) {
+	if cfg.Admin.Token == "" {
+		logger.Warn("admin endpoints are not protected by a token", zap.String("url", server.Addr))
+	}
+	logger.Info("admin listening", zap.String("url", server.Addr))
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Error("admin server", zap.Error(err))
+	}
+}
+
+// migrateUp applies the embedded migrations, replicas starting together wait
+// on the advisory lock taken by the migrator
+func migrateUp(cfg *config.Config, logger *zap.Logger) error {
+	migrator, err := postgres.NewMigrator(cfg, migrations.FS)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	if err := migrator.Up(); err != nil {
+		return fmt.Errorf("auto migrate: %w", err)
+	}
+
+	version, _, err := migrator.Version()
+	if err != nil {
+		return fmt.Errorf("auto migrate: %w", err)
+	}
+	logger.Info("database migrated", zap.Uint("version", version))
+
+	return nil
+}
+
+func (a *App) Run() error {
 	serviceClients, err := grpc_service_clients.New(a.Config)
 	if err != nil {
 		return fmt.Errorf("error during initialize service clients: %w", err)
 	}
 	a.ServiceClients = serviceClients
 
-	jobRepo := postgresql.NewJobRepo(a.DB)
+	jobRepo := repository.NewJobTracing(postgresql.NewJobRepo(a.DB))
+	clientRepo := repository.NewClientTracing(postgresql.NewClientRepo(a.DB))
 
-	jobUseCase := usecase.NewJobService(contextTimeout, jobRepo)
+	jobUseCase := usecase.NewJobMetrics(usecase.NewJobTracing(usecase.NewJobService(a.Config.Context.Timeout, jobRepo, clientRepo, a.DB, a.Config.List.MaxLimit)), a.Metrics)
 
 	pb.RegisterJobServiceServer(a.GrpcServer, services.NewRPC(a.Logger, jobUseCase))
 
-	// a.BrokerConsumer.Run()
+	clientUseCase := usecase.NewClientTracing(usecase.NewClientService(a.Config.Context.Timeout, clientRepo, jobRepo, a.DB, a.Config.Client.DeletePolicy, a.Config.List.MaxLimit))
+	clientpb.RegisterClientServiceServer(a.GrpcServer, services.NewClientRPC(a.Logger, clientUseCase))
+
+	// health service, registered services are served while postgres is reachable
+	for service := range a.GrpcServer.GetServiceInfo() {
+		a.Health.AddService(service, "postgres")
+	}
+	healthpb.RegisterHealthServer(a.GrpcServer, a.Health.Server())
+	a.Health.Start()
+
+	go func() {
+		a.Logger.Info("metrics listening", zap.String("url", a.Config.Metrics.Port))
+		if err := a.MetricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			a.Logger.Error("metrics server", zap.Error(err))
+		}
+	}()
+
+	if a.AdminServer != nil {
+		go serveAdmin(a.AdminServer, a.Config, a.Logger)
+	}
+
+	if a.GatewayServer != nil {
+		go func() {
+			a.Logger.Info("gateway listening", zap.String("url", a.Config.Gateway.Port))
+			if err := a.GatewayServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				a.Logger.Error("gateway server", zap.Error(err))
+			}
+		}()
+	}
 
 	a.Logger.Info("gRPC Server Listening", zap.String("url", a.Config.RPCPort))
 	if err := server.Run(a.Config, a.GrpcServer); err != nil {
@@ -94,18 +361,69 @@ func (a *App) Run() error {
 	return nil
 }
 
+// Stop shuts the app down in dependency order: the health service stops
+// advertising the server, in-flight RPCs are given until the shutdown timeout
+// to finish, and only then are the database pool and the trace exporter
+// closed, the exporter with a deadline of its own
 func (a *App) Stop() {
+	// report NOT_SERVING and give load balancers time to drain traffic
+	a.Health.Shutdown()
+	time.Sleep(a.Config.Health.DrainDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.Config.ShutdownTimeout)
+	defer cancel()
+
+	// the gateway first, its requests are RPCs to the server below
+	if a.GatewayServer != nil {
+		if err := a.GatewayServer.Shutdown(ctx); err != nil {
+			a.Logger.Error("gateway server shutdown", zap.Error(err))
+		}
+		a.GatewayConn.Close()
+	}
+
+	// stop accepting RPCs and wait for the in-flight ones until the deadline
+	stopped := make(chan struct{})
+	go func() {
+		a.GrpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		a.Logger.Warn("gRPC graceful stop timed out, aborting in-flight RPCs")
+		a.GrpcServer.Stop()
+		<-stopped
+	}
+
+	// metrics server
+	if err := a.MetricsServer.Shutdown(ctx); err != nil {
+		a.Logger.Error("metrics server shutdown", zap.Error(err))
+	}
+
+	// admin server
+	if a.AdminServer != nil {
+		if err := a.AdminServer.Shutdown(ctx); err != nil {
+			a.Logger.Error("admin server shutdown", zap.Error(err))
+		}
+	}
+
 	// closing client service connections
 	a.ServiceClients.Close()
-	// stop gRPC server
-	a.GrpcServer.Stop()
-
-	// database connection
-	a.DB.Close()
 
 	// broker consumer connection
 	a.BrokerConsumer.Close()
 
+	// database connection, no request can use it anymore
+	a.DB.Close()
+
+	// flush remaining spans, those of a slow shutdown included
+	flushCtx, flushCancel := context.WithTimeout(context.Background(), otlpFlushTimeout)
+	defer flushCancel()
+	if err := a.ShutdownOTLP(flushCtx); err != nil {
+		a.Logger.Error("otlp shutdown", zap.Error(err))
+	}
+
 	// zap logger sync
+	a.stopLevelSignal()
 	a.Logger.Sync()
 }
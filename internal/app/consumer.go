@@ -1,18 +1,24 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"fifth_exam/job_service/internal/delivery/kafka/handlers"
 	"fifth_exam/job_service/internal/infrastructure/kafka"
+	"fifth_exam/job_service/internal/infrastructure/repository"
 	"fifth_exam/job_service/internal/infrastructure/repository/postgresql"
+	"fifth_exam/job_service/internal/pkg/admin"
 	"fifth_exam/job_service/internal/pkg/config"
+	"fifth_exam/job_service/internal/pkg/metrics"
 	"fifth_exam/job_service/internal/pkg/postgres"
 	"fifth_exam/job_service/internal/usecase"
 	"fifth_exam/job_service/internal/usecase/event"
-	"fmt"
+	"net/http"
 	"time"
 
 	logpkg "fifth_exam/job_service/internal/pkg/logger"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 )
 
@@ -21,35 +27,72 @@ type JobConsumer struct {
 	Logger         *zap.Logger
 	DB             *postgres.PostgresDB
 	BrokerConsumer event.BrokerConsumer
+	Metrics        *prometheus.Registry
+	MetricsServer  *http.Server
+	// AdminServer is nil when the admin endpoints are disabled
+	AdminServer *http.Server
+	// LogLevel is changed at runtime by SIGUSR1 and the admin endpoint
+	LogLevel zap.AtomicLevel
+
+	stopLevelSignal func()
 }
 
 func NewJobConsumer(conf *config.Config) (*JobConsumer, error) {
-	logger, err := logpkg.New(conf.LogLevel, conf.Environment, conf.APP+"_cli"+".lo")
+	logger, logLevel, err := newLogger(conf, conf.APP+"_cli.log")
 	if err != nil {
 		return nil, err
 	}
 
-	consumer := kafka.NewConsumer(logger)
+	registry := metrics.NewRegistry()
+	consumer := kafka.NewConsumer(logger, registry)
 
 	db, err := postgres.New(conf)
 	if err != nil {
 		return nil, err
 	}
+	registry.MustRegister(postgres.NewPoolCollector(db))
+
+	var adminServer *http.Server
+	if conf.Admin.Enabled {
+		adminServer = admin.NewServer(conf.Admin.ConsumerPort, admin.Options{
+			Token:     conf.Admin.Token,
+			Config:    conf,
+			Gatherer:  registry,
+			Level:     logLevel,
+			Consumers: consumer,
+		})
+	}
+
+	return &JobConsumer{
+		Config:         conf,
+		Logger:         logger,
+		DB:             db,
+		BrokerConsumer: consumer,
+		Metrics:        registry,
+		MetricsServer:  metrics.NewServer(conf.Metrics.ConsumerPort, registry),
+		AdminServer:    adminServer,
+		LogLevel:       logLevel,
 
-	return &JobConsumer{Config: conf, Logger: logger, DB: db, BrokerConsumer: consumer}, nil
+		stopLevelSignal: logpkg.HandleLevelSignal(logLevel, logger),
+	}, nil
 }
 
 func (u *JobConsumer) Run() error {
 
-	// repo init
-	jobRepo := postgresql.NewJobRepo(u.DB)
-
 	// usecase init
-	duration, err := time.ParseDuration(u.Config.Context.Timeout)
-	if err != nil {
-		return fmt.Errorf("error during parse duration for context timeout : %w", err)
+	jobUseCase := usecase.NewJobMetrics(u.jobUsecase(), u.Metrics)
+
+	// metrics endpoint
+	go func() {
+		u.Logger.Info("consumer metrics listening", zap.String("url", u.Config.Metrics.ConsumerPort))
+		if err := u.MetricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			u.Logger.Error("consumer metrics server", zap.Error(err))
+		}
+	}()
+
+	if u.AdminServer != nil {
+		go serveAdmin(u.AdminServer, u.Config, u.Logger)
 	}
-	jobUseCase := usecase.NewJobService(duration, jobRepo)
 
 	// event handler
 	eventHandler := handlers.NewUserConsumerHandler(u.Config, u.BrokerConsumer, u.Logger, jobUseCase)
@@ -57,8 +100,36 @@ func (u *JobConsumer) Run() error {
 	return eventHandler.HandlerEvents()
 }
 
+// Replay reprocesses the job topic messages produced between from and to with
+// the same handler the consumer uses, committed group offsets are not touched
+func (u *JobConsumer) Replay(ctx context.Context, from, to time.Time) (kafka.ReplayStats, error) {
+	eventHandler := handlers.NewUserConsumerHandler(u.Config, u.BrokerConsumer, u.Logger, u.jobUsecase())
+
+	offsets := kafka.NewOffsetManager(u.Config.Kafka.Address)
+	return offsets.Replay(ctx, u.Config.Kafka.Topic.JobTopic, from, to, eventHandler.HandleJobCreate)
+}
+
+// jobUsecase returns the traced job usecase over the postgres repositories
+func (u *JobConsumer) jobUsecase() usecase.Job {
+	jobRepo := repository.NewJobTracing(postgresql.NewJobRepo(u.DB))
+	clientRepo := repository.NewClientTracing(postgresql.NewClientRepo(u.DB))
+	return usecase.NewJobTracing(usecase.NewJobService(u.Config.Context.Timeout, jobRepo, clientRepo, u.DB, u.Config.List.MaxLimit))
+}
+
 func (u *JobConsumer) Close() {
 	u.BrokerConsumer.Close()
 
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := u.MetricsServer.Shutdown(ctx); err != nil {
+		u.Logger.Error("consumer metrics server shutdown", zap.Error(err))
+	}
+	if u.AdminServer != nil {
+		if err := u.AdminServer.Shutdown(ctx); err != nil {
+			u.Logger.Error("consumer admin server shutdown", zap.Error(err))
+		}
+	}
+
+	u.stopLevelSignal()
 	u.Logger.Sync()
 }
@@ -0,0 +1,34 @@
+package app
+
+import (
+	"fifth_exam/job_service/internal/pkg/config"
+	logpkg "fifth_exam/job_service/internal/pkg/logger"
+	"path/filepath"
+
+	"go.uber.org/zap"
+)
+
+// newLogger builds the logger of a process writing its file output to name
+// in the log directory, the returned level changes it at runtime
+func newLogger(cfg *config.Config, name string) (*zap.Logger, zap.AtomicLevel, error) {
+	level := logpkg.NewLevel(cfg.LogLevel)
+	logger, err := logpkg.New(logpkg.Config{
+		Level:       level,
+		Environment: cfg.Environment,
+		Outputs:     cfg.Log.Outputs,
+		File:        filepath.Join(cfg.Log.Dir, name),
+		Rotation: logpkg.Rotation{
+			MaxSize:    cfg.Log.MaxSizeMB << 20,
+			MaxBackups: cfg.Log.MaxBackups,
+			MaxAge:     cfg.Log.MaxAge,
+			Interval:   cfg.Log.RotateInterval,
+		},
+	})
+	if err != nil {
+		return nil, level, err
+	}
+
+	// logger.FromContext falls back to it outside of RPCs and messages
+	zap.ReplaceGlobals(logger)
+	return logger, level, nil
+}
@@ -11,9 +11,11 @@ import (
 )
 
 var (
-	errNotFound   *entity.ErrNotFound
-	errConflict   *entity.ErrConflict
-	errValidation *entity.ErrValidation
+	errNotFound     *entity.ErrNotFound
+	errConflict     *entity.ErrConflict
+	errValidation   *entity.ErrValidation
+	errPrecondition *entity.ErrFailedPrecondition
+	errPermission   *entity.ErrPermissionDenied
 )
 
 func ErrorStatus(ctx context.Context, err error) *status.Status {
@@ -27,6 +29,12 @@ func ErrorStatus(ctx context.Context, err error) *status.Status {
 	// error conflict
 	case errors.As(err, &errConflict):
 		st = status.New(codes.AlreadyExists, err.Error())
+	// error permission denied
+	case errors.As(err, &errPermission):
+		st = status.New(codes.PermissionDenied, err.Error())
+	// error failed precondition
+	case errors.As(err, &errPrecondition):
+		st = status.New(codes.FailedPrecondition, err.Error())
 	// error validation errors
 	case errors.As(err, &errValidation):
 		st = status.New(codes.InvalidArgument, codes.InvalidArgument.String())
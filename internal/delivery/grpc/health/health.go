@@ -0,0 +1,155 @@
+// Package health keeps the grpc.health.v1 statuses of the server in sync with
+// periodic checks of its dependencies
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// CheckFunc reports whether a dependency is usable
+type CheckFunc func(ctx context.Context) error
+
+type check struct {
+	name string
+	fn   CheckFunc
+}
+
+// Checker runs the registered checks every interval. Each check is exposed as
+// a health service of its own name and every gRPC service is SERVING only
+// while all of its dependencies pass, the overall "" status follows the gRPC
+// services.
+type Checker struct {
+	logger   *zap.Logger
+	server   *health.Server
+	interval time.Duration
+	timeout  time.Duration
+
+	mu       sync.Mutex
+	checks   []check
+	services map[string][]string
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+func NewChecker(logger *zap.Logger, interval time.Duration) *Checker {
+	timeout := interval
+	if timeout > 5*time.Second {
+		timeout = 5 * time.Second
+	}
+
+	return &Checker{
+		logger:   logger,
+		server:   health.NewServer(),
+		interval: interval,
+		timeout:  timeout,
+		services: make(map[string][]string),
+	}
+}
+
+// Server returns the grpc.health.v1 implementation to register on the gRPC server
+func (c *Checker) Server() healthpb.HealthServer {
+	return c.server
+}
+
+func (c *Checker) AddCheck(name string, fn CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checks = append(c.checks, check{name: name, fn: fn})
+	c.server.SetServingStatus(name, healthpb.HealthCheckResponse_UNKNOWN)
+}
+
+// AddService registers a gRPC service whose status depends on the named checks
+func (c *Checker) AddService(service string, dependsOn ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.services[service] = dependsOn
+	c.server.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+	c.server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+// Start runs the checks once synchronously and then in the background until
+// Shutdown is called
+func (c *Checker) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.done = make(chan struct{})
+
+	c.run(ctx)
+
+	go func() {
+		defer close(c.done)
+
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.run(ctx)
+			}
+		}
+	}()
+}
+
+// Shutdown sets every service to NOT_SERVING and stops updating statuses, so
+// load balancers stop routing new requests to the server
+func (c *Checker) Shutdown() {
+	if c.cancel != nil {
+		c.cancel()
+		<-c.done
+	}
+	c.server.Shutdown()
+}
+
+func (c *Checker) run(ctx context.Context) {
+	c.mu.Lock()
+	checks := append([]check(nil), c.checks...)
+	services := make(map[string][]string, len(c.services))
+	for service, deps := range c.services {
+		services[service] = deps
+	}
+	c.mu.Unlock()
+
+	passed := make(map[string]bool, len(checks))
+	for _, chk := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+		err := chk.fn(checkCtx)
+		cancel()
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		passed[chk.name] = err == nil
+		if err != nil {
+			c.logger.Warn("health check failed", zap.String("check", chk.name), zap.Error(err))
+			c.server.SetServingStatus(chk.name, healthpb.HealthCheckResponse_NOT_SERVING)
+			continue
+		}
+		c.server.SetServingStatus(chk.name, healthpb.HealthCheckResponse_SERVING)
+	}
+
+	overall := healthpb.HealthCheckResponse_SERVING
+	for service, deps := range services {
+		status := healthpb.HealthCheckResponse_SERVING
+		for _, dep := range deps {
+			if !passed[dep] {
+				status = healthpb.HealthCheckResponse_NOT_SERVING
+				overall = healthpb.HealthCheckResponse_NOT_SERVING
+				break
+			}
+		}
+		c.server.SetServingStatus(service, status)
+	}
+	c.server.SetServingStatus("", overall)
+}
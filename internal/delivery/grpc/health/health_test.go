@@ -0,0 +1,63 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type CheckerTestSuite struct {
+	suite.Suite
+	Checker *Checker
+	DBErr   error
+}
+
+func (s *CheckerTestSuite) SetupTest() {
+	s.DBErr = nil
+	s.Checker = NewChecker(zap.NewNop(), time.Hour)
+	s.Checker.AddCheck("postgres", func(ctx context.Context) error { return s.DBErr })
+	s.Checker.AddCheck("kafka", func(ctx context.Context) error { return errors.New("no brokers") })
+	s.Checker.AddService("job.JobService", "postgres")
+}
+
+func (s *CheckerTestSuite) status(service string) healthpb.HealthCheckResponse_ServingStatus {
+	res, err := s.Checker.Server().Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	s.Suite.NoError(err)
+	return res.Status
+}
+
+func (s *CheckerTestSuite) TestServicesFollowDependencies() {
+	s.Checker.Start()
+	defer s.Checker.Shutdown()
+
+	s.Suite.Equal(healthpb.HealthCheckResponse_SERVING, s.status("job.JobService"))
+	s.Suite.Equal(healthpb.HealthCheckResponse_SERVING, s.status(""))
+	s.Suite.Equal(healthpb.HealthCheckResponse_SERVING, s.status("postgres"))
+	s.Suite.Equal(healthpb.HealthCheckResponse_NOT_SERVING, s.status("kafka"))
+}
+
+func (s *CheckerTestSuite) TestFailingDependency() {
+	s.DBErr = errors.New("connection refused")
+	s.Checker.Start()
+	defer s.Checker.Shutdown()
+
+	s.Suite.Equal(healthpb.HealthCheckResponse_NOT_SERVING, s.status("job.JobService"))
+	s.Suite.Equal(healthpb.HealthCheckResponse_NOT_SERVING, s.status(""))
+}
+
+func (s *CheckerTestSuite) TestShutdown() {
+	s.Checker.Start()
+	s.Checker.Shutdown()
+
+	s.Suite.Equal(healthpb.HealthCheckResponse_NOT_SERVING, s.status("job.JobService"))
+	s.Suite.Equal(healthpb.HealthCheckResponse_NOT_SERVING, s.status(""))
+}
+
+func TestCheckerTestSuite(t *testing.T) {
+	suite.Run(t, new(CheckerTestSuite))
+}
@@ -0,0 +1,116 @@
+package interceptors
+
+import (
+	"context"
+	"encoding/json"
+	logpkg "fifth_exam/job_service/internal/pkg/logger"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const redactedValue = "******"
+
+type AccessLog struct {
+	logger   *zap.Logger
+	payloads bool
+	redact   map[string]bool
+}
+
+// NewAccessLog logs one line per RPC. With payloads set the request and the
+// response are logged too, as JSON with the values of the redact fields,
+// matched case-insensitively at any depth, replaced.
+func NewAccessLog(logger *zap.Logger, payloads bool, redact []string) *AccessLog {
+	a := &AccessLog{
+		logger:   logger,
+		payloads: payloads,
+		redact:   make(map[string]bool, len(redact)),
+	}
+	for _, field := range redact {
+		a.redact[strings.ToLower(field)] = true
+	}
+	return a
+}
+
+func (a *AccessLog) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		started := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		fields := []zap.Field{
+			zap.String("grpc_method", info.FullMethod),
+			zap.String("grpc_code", code.String()),
+			zap.Duration("duration", time.Since(started)),
+		}
+		fields = append(fields, logpkg.Fields(ctx)...)
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			fields = append(fields, zap.String("peer", p.Addr.String()))
+		}
+		if err != nil {
+			fields = append(fields, zap.String("error", status.Convert(err).Message()))
+		}
+		if a.payloads {
+			fields = append(fields, zap.Any("request", a.payload(req)))
+			if err == nil {
+				fields = append(fields, zap.Any("response", a.payload(resp)))
+			}
+		}
+
+		a.logger.Log(accessLogLevel(code), "gRPC request", fields...)
+
+		return resp, err
+	}
+}
+
+// payload returns message as generic JSON values with sensitive fields
+// redacted
+func (a *AccessLog) payload(message interface{}) interface{} {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return nil
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil
+	}
+	return a.redactValue(value)
+}
+
+func (a *AccessLog) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if a.redact[strings.ToLower(key)] {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = a.redactValue(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = a.redactValue(item)
+		}
+	}
+	return value
+}
+
+// accessLogLevel logs failures caused by the server as errors and the ones
+// caused by the caller as warnings
+func accessLogLevel(code codes.Code) zapcore.Level {
+	switch code {
+	case codes.OK:
+		return zapcore.InfoLevel
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented, codes.Internal, codes.Unavailable, codes.DataLoss:
+		return zapcore.ErrorLevel
+	default:
+		return zapcore.WarnLevel
+	}
+}
@@ -0,0 +1,82 @@
+package interceptors
+
+import (
+	"context"
+	"fifth_exam/job_service/internal/pkg/auth"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const authorizationHeader = "authorization"
+
+type Auth struct {
+	verifier *auth.Verifier
+	public   []string
+}
+
+// NewAuth authenticates every RPC with the bearer token of its metadata
+// except for public ones, given as full method names or as service prefixes
+// such as "/grpc.health.v1.Health/"
+func NewAuth(verifier *auth.Verifier, public ...string) *Auth {
+	return &Auth{
+		verifier: verifier,
+		public:   public,
+	}
+}
+
+func (a *Auth) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if isPublic(info.FullMethod, a.public) {
+			return handler(ctx, req)
+		}
+
+		token, err := bearerToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		identity, err := a.verifier.Verify(token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		return handler(auth.WithIdentity(ctx, identity), req)
+	}
+}
+
+// isPublic reports whether method is one of public, given as full method
+// names or service prefixes
+func isPublic(method string, public []string) bool {
+	for _, public := range public {
+		if method == public || (strings.HasSuffix(public, "/") && strings.HasPrefix(method, public)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Anonymous puts identity into the context of every RPC, it stands in for
+// Auth when authentication is disabled
+func Anonymous(identity *auth.Identity) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(auth.WithIdentity(ctx, identity), req)
+	}
+}
+
+func bearerToken(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(authorizationHeader)
+	if len(values) == 0 {
+		return "", status.Error(codes.Unauthenticated, "authorization metadata is required")
+	}
+
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") || strings.TrimSpace(token) == "" {
+		return "", status.Error(codes.Unauthenticated, "authorization metadata must be a bearer token")
+	}
+	return strings.TrimSpace(token), nil
+}
@@ -0,0 +1,88 @@
+package interceptors
+
+import (
+	"context"
+	"fifth_exam/job_service/internal/pkg/auth"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type AuthTestSuite struct {
+	suite.Suite
+	Interceptor grpc.UnaryServerInterceptor
+}
+
+func (s *AuthTestSuite) SetupTest() {
+	verifier, err := auth.NewVerifier(auth.Config{HMACSecret: "secret"})
+	s.Suite.Require().NoError(err)
+	s.Interceptor = NewAuth(verifier, "/grpc.health.v1.Health/").UnaryServerInterceptor()
+}
+
+// call runs the interceptor and returns the identity seen by the handler
+func (s *AuthTestSuite) call(method string, md metadata.MD) (*auth.Identity, error) {
+	var identity *auth.Identity
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+	_, err := s.Interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		identity, _ = auth.FromContext(ctx)
+		return nil, nil
+	})
+	return identity, err
+}
+
+func (s *AuthTestSuite) TestAuthenticate() {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": "client-1",
+		"exp": time.Now().Add(time.Minute).Unix(),
+	}).SignedString([]byte("secret"))
+	s.Suite.Require().NoError(err)
+
+	identity, err := s.call("/job.JobService/Update", metadata.Pairs("authorization", "Bearer "+token))
+	s.Suite.NoError(err)
+	s.Suite.Equal("client-1", identity.Subject)
+
+	_, err = s.call("/job.JobService/Update", metadata.MD{})
+	s.Suite.Equal(codes.Unauthenticated, status.Code(err))
+
+	_, err = s.call("/job.JobService/Update", metadata.Pairs("authorization", "Basic "+token))
+	s.Suite.Equal(codes.Unauthenticated, status.Code(err))
+
+	_, err = s.call("/job.JobService/Update", metadata.Pairs("authorization", "Bearer not-a-token"))
+	s.Suite.Equal(codes.Unauthenticated, status.Code(err))
+
+	identity, err = s.call("/grpc.health.v1.Health/Check", metadata.MD{})
+	s.Suite.NoError(err)
+	s.Suite.Nil(identity)
+}
+
+func (s *AuthTestSuite) TestRBAC() {
+	policy, err := auth.NewPolicy(
+		map[string][]string{auth.RoleFreelancer: {"jobs:read"}},
+		map[string][]string{"/job.JobService/Get": {"jobs:read"}, "/job.JobService/Delete": {"jobs:delete"}},
+	)
+	s.Suite.Require().NoError(err)
+	rbac := NewRBAC(policy, "/grpc.health.v1.Health/").UnaryServerInterceptor()
+
+	call := func(ctx context.Context, method string) error {
+		_, err := rbac(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		return err
+	}
+
+	freelancer := auth.WithIdentity(context.Background(), &auth.Identity{Subject: "f", Roles: []string{auth.RoleFreelancer}})
+	s.Suite.NoError(call(freelancer, "/job.JobService/Get"))
+	s.Suite.Equal(codes.PermissionDenied, status.Code(call(freelancer, "/job.JobService/Delete")))
+	s.Suite.Equal(codes.Unauthenticated, status.Code(call(context.Background(), "/job.JobService/Get")))
+	s.Suite.NoError(call(context.Background(), "/grpc.health.v1.Health/Check"))
+}
+
+func TestAuthTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthTestSuite))
+}
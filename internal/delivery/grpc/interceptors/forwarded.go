@@ -0,0 +1,61 @@
+package interceptors
+
+import (
+	"context"
+	"crypto/subtle"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	// forwardedForHeader is the metadata key the gateway passes the address
+	// of its HTTP client in
+	forwardedForHeader = "x-forwarded-for"
+	// gatewayTokenHeader is the metadata key the gateway proves itself with
+	gatewayTokenHeader = "x-gateway-token"
+)
+
+// forwardedAddr is the address of a caller forwarded by the gateway, it has
+// no port
+type forwardedAddr string
+
+func (a forwardedAddr) Network() string { return "tcp" }
+
+func (a forwardedAddr) String() string { return string(a) }
+
+// Forwarded replaces the peer of RPCs made by the gateway with the HTTP
+// client the gateway forwarded them for, so that rate limits and access logs
+// see the client instead of the loopback connection of the gateway. Only
+// RPCs carrying token, which the gateway attaches with GatewayToken, are
+// trusted, x-forwarded-for from anyone else is ignored.
+func Forwarded(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		tokens, addrs := md.Get(gatewayTokenHeader), md.Get(forwardedForHeader)
+		if token == "" || len(tokens) == 0 || len(addrs) == 0 || subtle.ConstantTimeCompare([]byte(tokens[0]), []byte(token)) != 1 {
+			return handler(ctx, req)
+		}
+
+		ip := net.ParseIP(addrs[0])
+		if ip == nil {
+			return handler(ctx, req)
+		}
+		forwarded := &peer.Peer{Addr: forwardedAddr(ip.String())}
+		if p, ok := peer.FromContext(ctx); ok {
+			forwarded.AuthInfo = p.AuthInfo
+		}
+
+		return handler(peer.NewContext(ctx, forwarded), req)
+	}
+}
+
+// GatewayToken attaches token to every RPC of the gateway for Forwarded to
+// trust its x-forwarded-for
+func GatewayToken(token string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(metadata.AppendToOutgoingContext(ctx, gatewayTokenHeader, token), method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,113 @@
+package interceptors
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pb "fifth_exam/job_service/genproto/job_service"
+	"fifth_exam/job_service/internal/delivery/rest"
+	"fifth_exam/job_service/internal/pkg/ratelimit"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testGatewayToken = "gateway-token"
+
+type jobServerStub struct {
+	pb.UnimplementedJobServiceServer
+}
+
+func (*jobServerStub) Get(ctx context.Context, in *pb.JobRequest) (*pb.Job, error) {
+	return &pb.Job{Id: in.Value}, nil
+}
+
+// ForwardedTestSuite serves the gateway in front of a gRPC server limiting
+// every peer to a single request
+type ForwardedTestSuite struct {
+	suite.Suite
+	Logs    *observer.ObservedLogs
+	Gateway http.Handler
+}
+
+func (s *ForwardedTestSuite) SetupTest() {
+	core, logs := observer.New(zapcore.DebugLevel)
+	s.Logs = logs
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		Forwarded(testGatewayToken),
+		NewAccessLog(zap.New(core), false, nil).UnaryServerInterceptor(),
+		NewRateLimit(ratelimit.NewLimiter(time.Minute), ratelimit.Quota{Rate: 0.001, Burst: 1}, nil, false).UnaryServerInterceptor(),
+	))
+	pb.RegisterJobServiceServer(server, &jobServerStub{})
+	go server.Serve(listener)
+	s.T().Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(GatewayToken(testGatewayToken)),
+	)
+	s.Suite.Require().NoError(err)
+	s.T().Cleanup(func() { conn.Close() })
+	s.Gateway = rest.NewHandler(pb.NewJobServiceClient(conn), zap.NewNop(), nil)
+}
+
+func (s *ForwardedTestSuite) get(remoteAddr string) int {
+	r := httptest.NewRequest(http.MethodGet, "/v1/jobs/job-1", nil)
+	r.RemoteAddr = remoteAddr
+	w := httptest.NewRecorder()
+	s.Gateway.ServeHTTP(w, r)
+	return w.Code
+}
+
+func (s *ForwardedTestSuite) TestClientsGetTheirOwnBucket() {
+	s.Suite.Equal(http.StatusOK, s.get("203.0.113.1:50000"))
+	s.Suite.Equal(http.StatusOK, s.get("203.0.113.2:50000"))
+	// another connection of the first client shares its bucket
+	s.Suite.Equal(http.StatusTooManyRequests, s.get("203.0.113.1:50001"))
+
+	var peers []interface{}
+	for _, entry := range s.Logs.FilterMessage("gRPC request").All() {
+		peers = append(peers, entry.ContextMap()["peer"])
+	}
+	s.Suite.Equal([]interface{}{"203.0.113.1", "203.0.113.2", "203.0.113.1"}, peers)
+}
+
+func (s *ForwardedTestSuite) TestForwardedForIsTrustedOnlyWithToken() {
+	direct := &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 5000}}
+	peerOf := func(md metadata.MD) string {
+		ctx := metadata.NewIncomingContext(peer.NewContext(context.Background(), direct), md)
+		var addr string
+		_, err := Forwarded(testGatewayToken)(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			p, _ := peer.FromContext(ctx)
+			addr = p.Addr.String()
+			return nil, nil
+		})
+		s.Suite.NoError(err)
+		return addr
+	}
+
+	s.Suite.Equal("198.51.100.1", peerOf(metadata.Pairs(forwardedForHeader, "198.51.100.1", gatewayTokenHeader, testGatewayToken)))
+	s.Suite.Equal("127.0.0.1:5000", peerOf(metadata.Pairs(forwardedForHeader, "198.51.100.1")))
+	s.Suite.Equal("127.0.0.1:5000", peerOf(metadata.Pairs(forwardedForHeader, "198.51.100.1", gatewayTokenHeader, "guessed")))
+	s.Suite.Equal("127.0.0.1:5000", peerOf(metadata.Pairs(forwardedForHeader, "not an ip", gatewayTokenHeader, testGatewayToken)))
+}
+
+func TestForwardedTestSuite(t *testing.T) {
+	suite.Run(t, new(ForwardedTestSuite))
+}
@@ -0,0 +1,18 @@
+package interceptors
+
+import (
+	"context"
+	logpkg "fifth_exam/job_service/internal/pkg/logger"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// ContextLogger puts logger, named after the RPC, into the context of every
+// RPC. Handlers, usecases and repositories log with logger.FromContext and
+// get the trace, request and caller of the RPC on every line.
+func ContextLogger(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(logpkg.WithContext(ctx, logger.With(zap.String("grpc_method", info.FullMethod))), req)
+	}
+}
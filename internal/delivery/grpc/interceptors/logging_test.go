@@ -0,0 +1,113 @@
+package interceptors
+
+import (
+	"context"
+	pb "fifth_exam/job_service/genproto/job_service"
+	logpkg "fifth_exam/job_service/internal/pkg/logger"
+	"fifth_exam/job_service/internal/pkg/requestid"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type LoggingTestSuite struct {
+	suite.Suite
+	Logs   *observer.ObservedLogs
+	Logger *zap.Logger
+}
+
+func (s *LoggingTestSuite) SetupTest() {
+	core, logs := observer.New(zapcore.DebugLevel)
+	s.Logs = logs
+	s.Logger = zap.New(core)
+}
+
+func (s *LoggingTestSuite) TestRecovery() {
+	_, err := Recovery(s.Logger)(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/job.JobService/Get"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			panic("boom")
+		})
+	s.Suite.Equal(codes.Internal, status.Code(err))
+	s.Suite.Equal(1, s.Logs.FilterMessage("panic in gRPC handler").Len())
+}
+
+func (s *LoggingTestSuite) TestRequestID() {
+	var seen string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		seen = requestid.FromContext(ctx)
+		return nil, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/job.JobService/Get"}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestid.Header, "req-1"))
+	_, err := RequestID()(ctx, nil, info, handler)
+	s.Suite.NoError(err)
+	s.Suite.Equal("req-1", seen)
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestid.Header, "not valid\n"))
+	_, err = RequestID()(ctx, nil, info, handler)
+	s.Suite.NoError(err)
+	s.Suite.NotEqual("not valid\n", seen)
+	s.Suite.True(requestid.Valid(seen))
+}
+
+func (s *LoggingTestSuite) TestContextLogger() {
+	info := &grpc.UnaryServerInfo{FullMethod: "/job.JobService/Get"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		logpkg.FromContext(ctx).Info("job fetched")
+		return nil, nil
+	}
+
+	ctx := requestid.WithID(context.Background(), "req-3")
+	_, err := ContextLogger(s.Logger)(ctx, nil, info, handler)
+	s.Suite.NoError(err)
+
+	entries := s.Logs.FilterMessage("job fetched").All()
+	s.Suite.Require().Len(entries, 1)
+	s.Suite.Equal("/job.JobService/Get", entries[0].ContextMap()["grpc_method"])
+	s.Suite.Equal("req-3", entries[0].ContextMap()["request_id"])
+}
+
+func (s *LoggingTestSuite) TestAccessLog() {
+	interceptor := NewAccessLog(s.Logger, true, []string{"owner_id"}).UnaryServerInterceptor()
+	ctx := requestid.WithID(context.Background(), "req-2")
+	info := &grpc.UnaryServerInfo{FullMethod: "/job.JobService/Create"}
+
+	_, err := interceptor(ctx, &pb.Job{Title: "Backend", OwnerId: "secret-owner"}, info,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return &pb.Job{Id: "1", Title: "Backend", OwnerId: "secret-owner"}, nil
+		})
+	s.Suite.NoError(err)
+
+	entries := s.Logs.TakeAll()
+	s.Suite.Require().Len(entries, 1)
+	s.Suite.Equal(zapcore.InfoLevel, entries[0].Level)
+	fields := entries[0].ContextMap()
+	s.Suite.Equal("/job.JobService/Create", fields["grpc_method"])
+	s.Suite.Equal("OK", fields["grpc_code"])
+	s.Suite.Equal("req-2", fields["request_id"])
+	s.Suite.Equal(redactedValue, fields["request"].(map[string]interface{})["owner_id"])
+	s.Suite.Equal("Backend", fields["response"].(map[string]interface{})["title"])
+	s.Suite.Equal(redactedValue, fields["response"].(map[string]interface{})["owner_id"])
+
+	_, err = interceptor(ctx, &pb.Job{}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.InvalidArgument, "bad title")
+	})
+	s.Suite.Error(err)
+	entries = s.Logs.TakeAll()
+	s.Suite.Require().Len(entries, 1)
+	s.Suite.Equal(zapcore.WarnLevel, entries[0].Level)
+	s.Suite.Equal("bad title", entries[0].ContextMap()["error"])
+	s.Suite.NotContains(entries[0].ContextMap(), "response")
+}
+
+func TestLoggingTestSuite(t *testing.T) {
+	suite.Run(t, new(LoggingTestSuite))
+}
@@ -0,0 +1,50 @@
+package interceptors
+
+import (
+	"context"
+	"fifth_exam/job_service/internal/pkg/metrics"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+type Metrics struct {
+	handled  *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// NewMetrics registers the gRPC server request metrics in registerer
+func NewMetrics(registerer prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		handled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "grpc_server",
+			Name:      "handled_total",
+			Help:      "RPCs completed on the server, by method and status code.",
+		}, []string{"grpc_method", "grpc_code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "grpc_server",
+			Name:      "handling_seconds",
+			Help:      "Time taken by the server to handle RPCs, by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"grpc_method"}),
+	}
+	registerer.MustRegister(m.handled, m.duration)
+	return m
+}
+
+func (m *Metrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		started := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		m.duration.WithLabelValues(info.FullMethod).Observe(time.Since(started).Seconds())
+		m.handled.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+
+		return resp, err
+	}
+}
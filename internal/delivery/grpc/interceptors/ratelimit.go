@@ -0,0 +1,77 @@
+package interceptors
+
+import (
+	"context"
+	"fifth_exam/job_service/internal/pkg/auth"
+	"fifth_exam/job_service/internal/pkg/ratelimit"
+	"fmt"
+	"net"
+	"time"
+
+	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type RateLimit struct {
+	limiter    *ratelimit.Limiter
+	quota      ratelimit.Quota
+	methods    map[string]ratelimit.Quota
+	byIdentity bool
+}
+
+// NewRateLimit limits every caller to quota across all methods except the
+// ones given their own quota in methods, which get a bucket per method.
+// Callers are told apart by the subject of their identity when byIdentity is
+// set, it must then be chained after Auth, and by their peer address
+// otherwise.
+func NewRateLimit(limiter *ratelimit.Limiter, quota ratelimit.Quota, methods map[string]ratelimit.Quota, byIdentity bool) *RateLimit {
+	return &RateLimit{
+		limiter:    limiter,
+		quota:      quota,
+		methods:    methods,
+		byIdentity: byIdentity,
+	}
+}
+
+func (r *RateLimit) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		key, quota := r.caller(ctx), r.quota
+		if q, ok := r.methods[info.FullMethod]; ok {
+			key, quota = key+" "+info.FullMethod, q
+		}
+
+		if ok, wait := r.limiter.Allow(key, quota); !ok {
+			return nil, resourceExhausted(info.FullMethod, wait)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (r *RateLimit) caller(ctx context.Context) string {
+	if r.byIdentity {
+		if identity, ok := auth.FromContext(ctx); ok {
+			return "sub:" + identity.Subject
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		addr := p.Addr.String()
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			addr = host
+		}
+		return "peer:" + addr
+	}
+	return "peer:unknown"
+}
+
+func resourceExhausted(method string, wait time.Duration) error {
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf("rate limit of %s exceeded, retry in %s", method, wait.Round(time.Millisecond)))
+	if detailed, err := st.WithDetails(&epb.RetryInfo{RetryDelay: durationpb.New(wait)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
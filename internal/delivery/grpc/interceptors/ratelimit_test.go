@@ -0,0 +1,75 @@
+package interceptors
+
+import (
+	"context"
+	"fifth_exam/job_service/internal/pkg/auth"
+	"fifth_exam/job_service/internal/pkg/ratelimit"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	epb "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type RateLimitTestSuite struct {
+	suite.Suite
+	Interceptor grpc.UnaryServerInterceptor
+}
+
+func (s *RateLimitTestSuite) SetupTest() {
+	s.Interceptor = NewRateLimit(
+		ratelimit.NewLimiter(time.Minute),
+		ratelimit.Quota{Rate: 1, Burst: 3},
+		map[string]ratelimit.Quota{"/job.JobService/GetList": {Rate: 1, Burst: 1}},
+		true,
+	).UnaryServerInterceptor()
+}
+
+func (s *RateLimitTestSuite) call(ctx context.Context, method string) error {
+	_, err := s.Interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	return err
+}
+
+func (s *RateLimitTestSuite) TestMethodQuota() {
+	alice := auth.WithIdentity(context.Background(), &auth.Identity{Subject: "alice"})
+	bob := auth.WithIdentity(context.Background(), &auth.Identity{Subject: "bob"})
+
+	s.Suite.NoError(s.call(alice, "/job.JobService/GetList"))
+	err := s.call(alice, "/job.JobService/GetList")
+	s.Suite.Equal(codes.ResourceExhausted, status.Code(err))
+
+	var retry *epb.RetryInfo
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*epb.RetryInfo); ok {
+			retry = info
+		}
+	}
+	s.Suite.Require().NotNil(retry)
+	s.Suite.Positive(retry.RetryDelay.AsDuration())
+
+	// the method bucket is separate from the default one and from other callers
+	s.Suite.NoError(s.call(alice, "/job.JobService/Get"))
+	s.Suite.NoError(s.call(bob, "/job.JobService/GetList"))
+}
+
+func (s *RateLimitTestSuite) TestPeerAddress() {
+	first := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5000}})
+	// another connection from the same host shares the bucket
+	second := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5001}})
+
+	s.Suite.NoError(s.call(first, "/job.JobService/Get"))
+	s.Suite.NoError(s.call(second, "/job.JobService/Get"))
+	s.Suite.NoError(s.call(first, "/job.JobService/Get"))
+	s.Suite.Equal(codes.ResourceExhausted, status.Code(s.call(second, "/job.JobService/Get")))
+}
+
+func TestRateLimitTestSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitTestSuite))
+}
@@ -0,0 +1,43 @@
+package interceptors
+
+import (
+	"context"
+	"fifth_exam/job_service/internal/pkg/auth"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type RBAC struct {
+	policy *auth.Policy
+	public []string
+}
+
+// NewRBAC checks the identity put into the context by Auth against policy,
+// it must be chained after Auth and given the same public methods
+func NewRBAC(policy *auth.Policy, public ...string) *RBAC {
+	return &RBAC{
+		policy: policy,
+		public: public,
+	}
+}
+
+func (r *RBAC) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if isPublic(info.FullMethod, r.public) {
+			return handler(ctx, req)
+		}
+
+		identity, ok := auth.FromContext(ctx)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "caller is not authenticated")
+		}
+
+		if err := r.policy.Authorize(identity, info.FullMethod); err != nil {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+
+		return handler(ctx, req)
+	}
+}
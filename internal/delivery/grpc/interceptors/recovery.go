@@ -0,0 +1,31 @@
+package interceptors
+
+import (
+	"context"
+	logpkg "fifth_exam/job_service/internal/pkg/logger"
+	"runtime/debug"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Recovery turns a panic of the handlers it wraps into an Internal error and
+// logs it with its stack instead of letting it kill the process
+func Recovery(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logpkg.With(ctx, logger).Error("panic in gRPC handler",
+					zap.String("grpc_method", info.FullMethod),
+					zap.Any("panic", r),
+					zap.ByteString("stack", debug.Stack()),
+				)
+				resp, err = nil, status.Error(codes.Internal, codes.Internal.String())
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
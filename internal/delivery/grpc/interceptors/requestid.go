@@ -0,0 +1,30 @@
+package interceptors
+
+import (
+	"context"
+	"fifth_exam/job_service/internal/pkg/requestid"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestID puts the x-request-id of the incoming metadata, or a new id when
+// it is missing or invalid, into the context and returns it in the response
+// header
+func RequestID() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		var id string
+		if values := md.Get(requestid.Header); len(values) != 0 && requestid.Valid(values[0]) {
+			id = values[0]
+		} else {
+			id = requestid.New()
+		}
+
+		// the header can only fail to be set outside of a server transport
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestid.Header, id))
+
+		return handler(requestid.WithID(ctx, id), req)
+	}
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	pb "fifth_exam/job_service/genproto/client_service"
+	delivery "fifth_exam/job_service/internal/delivery"
+	"fifth_exam/job_service/internal/entity"
+	logpkg "fifth_exam/job_service/internal/pkg/logger"
+	"fifth_exam/job_service/internal/usecase"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"go.uber.org/zap"
+)
+
+type clientRPC struct {
+	logger        *zap.Logger
+	clientUsecase usecase.Client
+}
+
+func NewClientRPC(logger *zap.Logger, clientUsecase usecase.Client) pb.ClientServiceServer {
+	return &clientRPC{
+		logger:        logger,
+		clientUsecase: clientUsecase,
+	}
+}
+
+func (c *clientRPC) Create(ctx context.Context, in *pb.Client) (*pb.Client, error) {
+	client, err := c.clientUsecase.Create(ctx, &entity.Client{
+		Username: in.Username,
+		Email:    in.Email,
+		Phone:    in.Phone,
+		Address:  in.Address,
+	})
+	if err != nil {
+		logpkg.With(ctx, c.logger).Error("clientUseCase.Create", zap.Error(err))
+		return nil, delivery.Error(ctx, err)
+	}
+
+	return clientToPB(client), nil
+}
+
+func (c *clientRPC) Get(ctx context.Context, in *pb.ClientRequest) (*pb.Client, error) {
+	client, err := c.clientUsecase.Get(ctx, in.Field, in.Value)
+	if err != nil {
+		logpkg.With(ctx, c.logger).Error("clientUseCase.Get", zap.Error(err))
+		return nil, delivery.Error(ctx, err)
+	}
+
+	return clientToPB(client), nil
+}
+
+func (c *clientRPC) Update(ctx context.Context, in *pb.Client) (*pb.Client, error) {
+	err := c.clientUsecase.Update(ctx, &entity.Client{
+		Id:       in.Id,
+		Username: in.Username,
+		Email:    in.Email,
+		Phone:    in.Phone,
+		Address:  in.Address,
+	})
+	if err != nil {
+		logpkg.With(ctx, c.logger).Error("clientUseCase.Update", zap.Error(err))
+		return nil, delivery.Error(ctx, err)
+	}
+
+	client, err := c.clientUsecase.Get(ctx, "id", in.Id)
+	if err != nil {
+		logpkg.With(ctx, c.logger).Error("clientUseCase.Get", zap.Error(err))
+		return nil, delivery.Error(ctx, err)
+	}
+
+	return clientToPB(client), nil
+}
+
+func (c *clientRPC) Activate(ctx context.Context, in *pb.ClientRequest) (*pb.Client, error) {
+	return c.setActive(ctx, in, true)
+}
+
+func (c *clientRPC) Deactivate(ctx context.Context, in *pb.ClientRequest) (*pb.Client, error) {
+	return c.setActive(ctx, in, false)
+}
+
+func (c *clientRPC) setActive(ctx context.Context, in *pb.ClientRequest, active bool) (*pb.Client, error) {
+	if err := c.clientUsecase.SetActive(ctx, in.Field, in.Value, active); err != nil {
+		logpkg.With(ctx, c.logger).Error("clientUseCase.SetActive", zap.Error(err))
+		return nil, delivery.Error(ctx, err)
+	}
+
+	client, err := c.clientUsecase.Get(ctx, in.Field, in.Value)
+	if err != nil {
+		logpkg.With(ctx, c.logger).Error("clientUseCase.Get", zap.Error(err))
+		return nil, delivery.Error(ctx, err)
+	}
+
+	return clientToPB(client), nil
+}
+
+func (c *clientRPC) Delete(ctx context.Context, in *pb.ClientRequest) (*empty.Empty, error) {
+	if err := c.clientUsecase.Delete(ctx, in.Field, in.Value); err != nil {
+		logpkg.With(ctx, c.logger).Error("clientUseCase.Delete", zap.Error(err))
+		return nil, delivery.Error(ctx, err)
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (c *clientRPC) GetList(ctx context.Context, in *pb.GetListFilter) (*pb.Clients, error) {
+	clients, err := c.clientUsecase.List(ctx, &entity.GetListFilter{
+		Page:           in.Page,
+		Limit:          in.Limit,
+		Search:         in.Search,
+		OrderBy:        in.OrderBy,
+		IncludeDeleted: in.IncludeDeleted,
+	})
+	if err != nil {
+		logpkg.With(ctx, c.logger).Error("clientUseCase.List", zap.Error(err))
+		return nil, delivery.Error(ctx, err)
+	}
+
+	var pbClients pb.Clients
+	for _, client := range clients {
+		pbClients.Clients = append(pbClients.Clients, clientToPB(client))
+		pbClients.Count++
+	}
+
+	return &pbClients, nil
+}
+
+func clientToPB(client *entity.Client) *pb.Client {
+	return &pb.Client{
+		Id:        client.Id,
+		Username:  client.Username,
+		Email:     client.Email,
+		Phone:     client.Phone,
+		Address:   client.Address,
+		IsActive:  client.IsActive,
+		IsDeleted: client.IsDeleted,
+		CreatedAt: formatTime(client.CreatedAt),
+		UpdatedAt: formatTime(client.UpdatedAt),
+		DeletedAt: formatTime(client.DeletedAt),
+	}
+}
+
+// formatTime renders zero times, e.g. a client never updated, as ""
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -3,23 +3,15 @@ package services
 import (
 	"context"
 	pb "fifth_exam/job_service/genproto/job_service"
+	delivery "fifth_exam/job_service/internal/delivery"
 	"fifth_exam/job_service/internal/entity"
-	"fifth_exam/job_service/internal/pkg/otlp"
+	logpkg "fifth_exam/job_service/internal/pkg/logger"
 	"fifth_exam/job_service/internal/usecase"
 	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/google/uuid"
-	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
-)
-
-const (
-	serviceNameJob = "jobService"
-	spanNameJob    = "jobUsecase"
 )
 
 type jobRPC struct {
@@ -35,14 +27,8 @@ func NewRPC(logger *zap.Logger, jobUsecase usecase.Job) pb.JobServiceServer {
 }
 
 func (j *jobRPC) Create(ctx context.Context, in *pb.Job) (*pb.Job, error) {
-	ctx, span := otlp.Start(ctx, serviceNameJob, spanNameJob+"Create")
-	defer span.End()
-
-	span.SetAttributes(attribute.KeyValue{Key: "delivery", Value: attribute.StringValue("CreatingJob")})
-	ctxWithSpan := trace.ContextWithSpan(ctx, span)
-
 	id := uuid.New().String()
-	_, err := j.jobUsecase.Create(ctxWithSpan, &entity.Job{
+	_, err := j.jobUsecase.Create(ctx, &entity.Job{
 		Id:          id,
 		Title:       in.Title,
 		OwnerId:     in.OwnerId,
@@ -53,21 +39,15 @@ func (j *jobRPC) Create(ctx context.Context, in *pb.Job) (*pb.Job, error) {
 		ToDate:      in.ToDate,
 	})
 	if err != nil {
-		j.logger.Error("jobUseCase.Create", zap.Error(err))
-		return &pb.Job{}, status.Errorf(codes.Internal, "failed to create job: %v", err)
+		logpkg.With(ctx, j.logger).Error("jobUseCase.Create", zap.Error(err))
+		return &pb.Job{}, delivery.Error(ctx, err)
 	}
 	in.Id = id
 	return in, nil
 }
 
 func (j *jobRPC) Update(ctx context.Context, in *pb.Job) (*pb.Job, error) {
-	ctx, span := otlp.Start(ctx, serviceNameJob, spanNameJob+"Update")
-	defer span.End()
-
-	span.SetAttributes(attribute.KeyValue{Key: "delivery", Value: attribute.StringValue("UpdatingJob")})
-	ctxWithSpan := trace.ContextWithSpan(ctx, span)
-
-	err := j.jobUsecase.Update(ctxWithSpan, &entity.Job{
+	err := j.jobUsecase.Update(ctx, &entity.Job{
 		Id:          in.Id,
 		Title:       in.Title,
 		OwnerId:     in.OwnerId,
@@ -78,90 +58,90 @@ func (j *jobRPC) Update(ctx context.Context, in *pb.Job) (*pb.Job, error) {
 		ToDate:      in.ToDate,
 	})
 	if err != nil {
-		j.logger.Error("jobUseCase.Update", zap.Error(err))
-		return &pb.Job{}, status.Errorf(codes.Internal, "failed to update job: %v", err)
+		logpkg.With(ctx, j.logger).Error("jobUseCase.Update", zap.Error(err))
+		return &pb.Job{}, delivery.Error(ctx, err)
 	}
 
 	return in, nil
 }
 
 func (j *jobRPC) Get(ctx context.Context, in *pb.JobRequest) (*pb.Job, error) {
-	ctx, span := otlp.Start(ctx, serviceNameJob, spanNameJob+"Get")
-	defer span.End()
-
-	span.SetAttributes(attribute.KeyValue{Key: "delivery", Value: attribute.StringValue("GettingJob")})
-	ctxWithSpan := trace.ContextWithSpan(ctx, span)
-
-	job, err := j.jobUsecase.Get(ctxWithSpan, in.Field, in.Value)
+	job, err := j.jobUsecase.Get(ctx, in.Field, in.Value)
 	if err != nil {
-		j.logger.Error("jobUseCase.Get", zap.Error(err))
-		return &pb.Job{}, status.Errorf(codes.Internal, "failed to get job: %v", err)
+		logpkg.With(ctx, j.logger).Error("jobUseCase.Get", zap.Error(err))
+		return &pb.Job{}, delivery.Error(ctx, err)
 	}
 
-	return &pb.Job{
-		Id:          job.Id,
-		Title:       job.Title,
-		OwnerId:     job.OwnerId,
-		Price:       job.Price,
-		Description: job.Description,
-		CreatedAt:   job.CreatedAt.String(),
-		UpdatedAt:   job.UpdatedAt.String(),
-		FromDate:    job.FromDate,
-		ToDate:      job.ToDate,
-	}, nil
+	return jobToPB(job, time.Now()), nil
 }
 
 func (j *jobRPC) Delete(ctx context.Context, in *pb.JobRequest) (*empty.Empty, error) {
-	ctx, span := otlp.Start(ctx, serviceNameJob, spanNameJob+"Delete")
-	defer span.End()
-
-	span.SetAttributes(attribute.KeyValue{Key: "delivery", Value: attribute.StringValue("DeletingJob")})
-	ctxWithSpan := trace.ContextWithSpan(ctx, span)
-
-	err := j.jobUsecase.Delete(ctxWithSpan, in.Field, in.Value)
+	err := j.jobUsecase.Delete(ctx, in.Field, in.Value)
 	if err != nil {
-		j.logger.Error("jobUseCase.Delete", zap.Error(err))
-		return &empty.Empty{}, status.Errorf(codes.Internal, "failed to delete job: %v", err)
+		logpkg.With(ctx, j.logger).Error("jobUseCase.Delete", zap.Error(err))
+		return &empty.Empty{}, delivery.Error(ctx, err)
 	}
 
 	return &empty.Empty{}, nil
 }
 
 func (j *jobRPC) GetList(ctx context.Context, in *pb.GetListFilter) (*pb.Jobs, error) {
-	ctx, span := otlp.Start(ctx, serviceNameJob, spanNameJob+"List")
-	defer span.End()
-
-	span.SetAttributes(attribute.KeyValue{Key: "delivery", Value: attribute.StringValue("GettingJobList")})
-	ctxWithSpan := trace.ContextWithSpan(ctx, span)
-
 	filter := &entity.GetListFilter{
 		Limit:   in.Limit,
 		Page:    in.Page,
 		OrderBy: in.OrderBy,
 	}
 
-	jobs, err := j.jobUsecase.List(ctxWithSpan, filter)
+	jobs, err := j.jobUsecase.List(ctx, filter)
 	if err != nil {
-		j.logger.Error("jobUseCase.List", zap.Error(err))
-		return nil, status.Errorf(codes.Internal, "failed to retrieve jobs: %v", err)
+		logpkg.With(ctx, j.logger).Error("jobUseCase.List", zap.Error(err))
+		return nil, delivery.Error(ctx, err)
 	}
 
+	return jobsToPB(jobs), nil
+}
+
+func (j *jobRPC) ListJobsByOwner(ctx context.Context, in *pb.ListJobsByOwnerRequest) (*pb.Jobs, error) {
+	jobs, err := j.jobUsecase.ListByOwner(ctx, in.OwnerId, &entity.GetListFilter{
+		Page:           in.Page,
+		Limit:          in.Limit,
+		OrderBy:        in.OrderBy,
+		IncludeDeleted: in.IncludeDeleted,
+	})
+	if err != nil {
+		logpkg.With(ctx, j.logger).Error("jobUseCase.ListByOwner", zap.Error(err))
+		return nil, delivery.Error(ctx, err)
+	}
+
+	return jobsToPB(jobs), nil
+}
+
+func jobsToPB(jobs []*entity.Job) *pb.Jobs {
+	now := time.Now()
+
 	var pbJobs pb.Jobs
 	for _, job := range jobs {
-		pbJob := &pb.Job{
-			Id:          job.Id,
-			Title:       job.Title,
-			OwnerId:     job.OwnerId,
-			Price:       job.Price,
-			Description: job.Description,
-			CreatedAt:   job.CreatedAt.String(),
-			UpdatedAt:   job.UpdatedAt.String(),
-			FromDate:    job.FromDate,
-			ToDate:      job.ToDate,
-		}
-		pbJobs.Jobs = append(pbJobs.Jobs, pbJob)
+		pbJobs.Jobs = append(pbJobs.Jobs, jobToPB(job, now))
 		pbJobs.Count++
 	}
+	return &pbJobs
+}
 
-	return &pbJobs, nil
+func jobToPB(job *entity.Job, now time.Time) *pb.Job {
+	pbJob := &pb.Job{
+		Id:          job.Id,
+		Title:       job.Title,
+		OwnerId:     job.OwnerId,
+		Price:       job.Price,
+		Description: job.Description,
+		CreatedAt:   job.CreatedAt.String(),
+		UpdatedAt:   job.UpdatedAt.String(),
+		FromDate:    job.FromDate,
+		ToDate:      job.ToDate,
+		Status:      job.Status(now),
+	}
+	if !job.CancelledAt.IsZero() {
+		pbJob.CancelledAt = job.CancelledAt.String()
+	}
+	return pbJob
 }
@@ -3,16 +3,18 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	pb "fifth_exam/job_service/genproto/job_service"
 	"fifth_exam/job_service/internal/entity"
 	"fifth_exam/job_service/internal/infrastructure/kafka"
 	"fifth_exam/job_service/internal/pkg/config"
+	logpkg "fifth_exam/job_service/internal/pkg/logger"
 	"fifth_exam/job_service/internal/usecase"
 	"fifth_exam/job_service/internal/usecase/event"
-	pb 	"fifth_exam/job_service/genproto/job_service"
 	"fmt"
 	"time"
 
-	"github.com/k0kubun/pp"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -39,35 +41,8 @@ func (u *jobConsumerHandler) HandlerEvents() error {
 	consumerConfig := kafka.NewConsumerConfig(
 		u.config.Kafka.Address,
 		u.config.Kafka.Topic.JobTopic,
-		"1",
-		func(ctx context.Context, key, value []byte) error {
-			var job pb.Job
-
-			if err := json.Unmarshal(value, &job); err != nil {
-				return err
-			}
-
-			pp.Println(job)
-
-			req := entity.Job{
-				Id:          job.Id,
-				Title:       job.Title,
-				Description: job.Description,
-				OwnerId:     job.OwnerId,
-				Price:       job.Price,
-			}
-
-			ctxNew, err := context.WithTimeout(context.Background(), time.Second*7)
-			if err != nil {
-				fmt.Println(err, "Context error")
-			}
-			_, errr := u.jobUsecase.Create(ctxNew, &req)
-			if errr != nil {
-				fmt.Println(errr, "Create Job By Kafka Error")
-			}
-
-			return nil
-		},
+		u.config.Kafka.ConsumerGroup,
+		u.HandleJobCreate,
 	)
 
 	u.brokerConsumer.RegisterConsumer(consumerConfig)
@@ -75,3 +50,98 @@ func (u *jobConsumerHandler) HandlerEvents() error {
 
 	return nil
 }
+
+// HandleJobCreate creates a job from a job topic message. Messages that can
+// never create a job, invalid ones and jobs that already exist, are skipped
+// with event.Skip, other failures are returned for the message to be retried.
+func (u *jobConsumerHandler) HandleJobCreate(ctx context.Context, key, value []byte) error {
+	req, err := DecodeJob(value)
+	if err != nil {
+		return event.Skip(fmt.Errorf("decode job message: %w", err))
+	}
+
+	log := logpkg.FromContext(ctx).With(zap.String("job_id", req.Id), zap.String("owner_id", req.OwnerId))
+	log.Debug("job message received")
+
+	// the context of the message carries its span and logger
+	ctx, cancel := context.WithTimeout(ctx, time.Second*7)
+	defer cancel()
+	if _, err := u.jobUsecase.Create(ctx, req); err != nil {
+		err = fmt.Errorf("create job %s: %w", req.Id, err)
+		if permanent(err) {
+			return event.Skip(err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// permanent reports whether creating the job of a message failed for a
+// reason that a later attempt can't fix: an invalid job or owner, or a job
+// that was already created
+func permanent(err error) bool {
+	var (
+		errValidation *entity.ErrValidation
+		errConflict   *entity.ErrConflict
+	)
+	return errors.As(err, &errValidation) || errors.As(err, &errConflict)
+}
+
+// ValidateJobCreate decodes and validates a job topic message without
+// creating the job, it has the kafka.HandlerFunc signature for dry runs
+func ValidateJobCreate(ctx context.Context, key, value []byte) error {
+	_, err := DecodeJob(value)
+	return err
+}
+
+// DecodeJob unmarshals a job topic message and validates the fields a job
+// needs to be created
+func DecodeJob(value []byte) (*entity.Job, error) {
+	var job pb.Job
+
+	if err := json.Unmarshal(value, &job); err != nil {
+		return nil, err
+	}
+
+	errValidation := entity.NewErrValidation()
+	if _, err := uuid.Parse(job.Id); err != nil {
+		errValidation.Errors["id"] = "must be a valid uuid"
+	}
+	if job.Title == "" {
+		errValidation.Errors["title"] = "is required"
+	}
+	if _, err := uuid.Parse(job.OwnerId); err != nil {
+		errValidation.Errors["owner_id"] = "must be a valid uuid"
+	}
+	if job.Price < 0 {
+		errValidation.Errors["price"] = "must not be negative"
+	}
+	from, fromErr := time.Parse(entity.JobDateLayout, job.FromDate)
+	if fromErr != nil {
+		errValidation.Errors["from_date"] = "must be a date like " + entity.JobDateLayout
+	}
+	to, toErr := time.Parse(entity.JobDateLayout, job.ToDate)
+	if toErr != nil {
+		errValidation.Errors["to_date"] = "must be a date like " + entity.JobDateLayout
+	}
+	if fromErr == nil && toErr == nil && to.Before(from) {
+		errValidation.Errors["to_date"] = "must not be before from_date"
+	}
+	if len(errValidation.Errors) != 0 {
+		errValidation.Err = errors.New("invalid job message")
+		return nil, errValidation
+	}
+
+	// like jobs created over gRPC the job is created now
+	return &entity.Job{
+		Id:          job.Id,
+		Title:       job.Title,
+		Description: job.Description,
+		OwnerId:     job.OwnerId,
+		Price:       job.Price,
+		FromDate:    job.FromDate,
+		ToDate:      job.ToDate,
+		CreatedAt:   time.Now(),
+	}, nil
+}
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	pb "fifth_exam/job_service/genproto/job_service"
+	"fifth_exam/job_service/internal/entity"
+	"fifth_exam/job_service/internal/infrastructure/inmemory"
+	"fifth_exam/job_service/internal/pkg/config"
+	"fifth_exam/job_service/internal/usecase/event"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type jobUsecaseStub struct {
+	mu      sync.Mutex
+	created []*entity.Job
+	// err fails every creation when it is set
+	err error
+}
+
+func (j *jobUsecaseStub) Create(ctx context.Context, req *entity.Job) (*entity.Job, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.err != nil {
+		return nil, j.err
+	}
+	j.created = append(j.created, req)
+	return req, nil
+}
+
+func (j *jobUsecaseStub) Get(ctx context.Context, field, value string) (*entity.Job, error) {
+	return nil, entity.ErrorNotFound
+}
+
+func (j *jobUsecaseStub) List(ctx context.Context, req *entity.GetListFilter) ([]*entity.Job, error) {
+	return nil, nil
+}
+
+func (j *jobUsecaseStub) ListByOwner(ctx context.Context, ownerId string, req *entity.GetListFilter) ([]*entity.Job, error) {
+	return nil, nil
+}
+
+func (j *jobUsecaseStub) Update(ctx context.Context, req *entity.Job) error {
+	return nil
+}
+
+func (j *jobUsecaseStub) Delete(ctx context.Context, field, value string) error {
+	return nil
+}
+
+// newJob returns a valid job topic message
+func newJob() *pb.Job {
+	return &pb.Job{
+		Id:       uuid.New().String(),
+		Title:    "New title",
+		OwnerId:  uuid.New().String(),
+		FromDate: "2024-05-01",
+		ToDate:   "2024-05-31",
+	}
+}
+
+type JobConsumerHandlerTestSuite struct {
+	suite.Suite
+	Config  *config.Config
+	Broker  *inmemory.Broker
+	Usecase *jobUsecaseStub
+}
+
+func (s *JobConsumerHandlerTestSuite) SetupTest() {
+	s.Config = config.New()
+	s.Broker = inmemory.NewBroker(2)
+	s.Usecase = &jobUsecaseStub{}
+}
+
+func (s *JobConsumerHandlerTestSuite) TearDownTest() {
+	s.Broker.Close()
+}
+
+func (s *JobConsumerHandlerTestSuite) TestHandlerCreatesJobs() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	consumer := s.Broker.Consumer()
+	handler := NewUserConsumerHandler(s.Config, consumer, zap.NewNop(), s.Usecase)
+	s.Suite.NoError(handler.HandlerEvents())
+	defer consumer.Close()
+
+	job := &pb.Job{
+		Id:          uuid.New().String(),
+		Title:       "New title",
+		Description: "New description",
+		OwnerId:     uuid.New().String(),
+		Price:       120.5,
+		FromDate:    "2024-05-01",
+		ToDate:      "2024-05-31",
+	}
+	value, err := json.Marshal(job)
+	s.Suite.NoError(err)
+	s.Suite.NoError(s.Broker.Produce(ctx, s.Config.Kafka.Topic.JobTopic, []byte(job.Id), value))
+
+	s.Suite.NoError(s.Broker.WaitCommitted(ctx, s.Config.Kafka.ConsumerGroup, s.Config.Kafka.Topic.JobTopic))
+
+	s.Usecase.mu.Lock()
+	defer s.Usecase.mu.Unlock()
+	s.Suite.Len(s.Usecase.created, 1)
+	created := s.Usecase.created[0]
+	s.Suite.Equal(job.Id, created.Id)
+	s.Suite.Equal(job.Title, created.Title)
+	s.Suite.Equal(job.Description, created.Description)
+	s.Suite.Equal(job.OwnerId, created.OwnerId)
+	s.Suite.Equal(job.Price, created.Price)
+	s.Suite.Equal(job.FromDate, created.FromDate)
+	s.Suite.Equal(job.ToDate, created.ToDate)
+	s.Suite.WithinDuration(time.Now(), created.CreatedAt, 2*time.Second)
+}
+
+func (s *JobConsumerHandlerTestSuite) TestDecodeJobDates() {
+	job := newJob()
+	for _, dates := range [][2]string{{"", "2024-05-31"}, {"2024-05-01", "31.05.2024"}, {"2024-05-31", "2024-05-01"}} {
+		job.FromDate, job.ToDate = dates[0], dates[1]
+		value, err := json.Marshal(job)
+		s.Suite.NoError(err)
+
+		_, err = DecodeJob(value)
+		var errValidation *entity.ErrValidation
+		s.Suite.ErrorAs(err, &errValidation, "%v is invalid", dates)
+	}
+}
+
+func (s *JobConsumerHandlerTestSuite) TestInvalidMessageIsSkipped() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	handler := NewUserConsumerHandler(s.Config, nil, zap.NewNop(), s.Usecase)
+	for _, value := range []string{`{"id":"not-a-uuid"}`, `not json`} {
+		err := handler.HandleJobCreate(ctx, nil, []byte(value))
+		s.Suite.True(event.Skipped(err), "%s is skipped", value)
+	}
+
+	consumer := s.Broker.Consumer()
+	handler = NewUserConsumerHandler(s.Config, consumer, zap.NewNop(), s.Usecase)
+	s.Suite.NoError(handler.HandlerEvents())
+	defer consumer.Close()
+
+	value, err := json.Marshal(&pb.Job{Id: "not-a-uuid"})
+	s.Suite.NoError(err)
+	s.Suite.NoError(s.Broker.Produce(ctx, s.Config.Kafka.Topic.JobTopic, nil, value))
+
+	s.Suite.NoError(s.Broker.WaitCommitted(ctx, s.Config.Kafka.ConsumerGroup, s.Config.Kafka.Topic.JobTopic))
+	s.Suite.Empty(s.Usecase.created)
+	s.Suite.Equal(int64(1), consumer.Consumers()[0].Skipped)
+}
+
+func (s *JobConsumerHandlerTestSuite) TestFailedCreateIsNotCommitted() {
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	errPostgres := errors.New("connection refused")
+	s.Usecase.err = errPostgres
+	value, err := json.Marshal(newJob())
+	s.Suite.NoError(err)
+
+	handler := NewUserConsumerHandler(s.Config, nil, zap.NewNop(), s.Usecase)
+	err = handler.HandleJobCreate(ctx, nil, value)
+	s.Suite.ErrorIs(err, errPostgres)
+	s.Suite.False(event.Skipped(err))
+
+	consumer := s.Broker.Consumer()
+	handler = NewUserConsumerHandler(s.Config, consumer, zap.NewNop(), s.Usecase)
+	s.Suite.NoError(handler.HandlerEvents())
+	defer consumer.Close()
+
+	s.Suite.NoError(s.Broker.Produce(ctx, s.Config.Kafka.Topic.JobTopic, nil, value))
+
+	s.Suite.ErrorIs(s.Broker.WaitCommitted(ctx, s.Config.Kafka.ConsumerGroup, s.Config.Kafka.Topic.JobTopic), context.DeadlineExceeded)
+}
+
+func (s *JobConsumerHandlerTestSuite) TestDuplicateJobIsCommitted() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	s.Usecase.err = entity.ErrorConflict
+
+	consumer := s.Broker.Consumer()
+	handler := NewUserConsumerHandler(s.Config, consumer, zap.NewNop(), s.Usecase)
+	s.Suite.NoError(handler.HandlerEvents())
+	defer consumer.Close()
+
+	value, err := json.Marshal(newJob())
+	s.Suite.NoError(err)
+	s.Suite.NoError(s.Broker.Produce(ctx, s.Config.Kafka.Topic.JobTopic, nil, value))
+
+	s.Suite.NoError(s.Broker.WaitCommitted(ctx, s.Config.Kafka.ConsumerGroup, s.Config.Kafka.Topic.JobTopic))
+	s.Suite.Equal(int64(1), consumer.Consumers()[0].Skipped)
+}
+
+func TestJobConsumerHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(JobConsumerHandlerTestSuite))
+}
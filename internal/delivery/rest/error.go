@@ -0,0 +1,85 @@
+package rest
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+
+	"go.uber.org/zap"
+	errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// errorBody is the JSON of a failed call, the gRPC status with its details
+// such as the field violations of invalid requests
+type errorBody struct {
+	Code    int32             `json:"code"`
+	Message string            `json:"message"`
+	Details []json.RawMessage `json:"details"`
+}
+
+// HTTPStatus returns the HTTP status of a gRPC code
+func HTTPStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		// client closed request, as used by nginx
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (g *gateway) writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+
+	body := errorBody{
+		Code:    int32(st.Code()),
+		Message: st.Message(),
+		Details: []json.RawMessage{},
+	}
+	for _, detail := range st.Proto().GetDetails() {
+		data, err := protojson.Marshal(detail)
+		if err != nil {
+			g.logger.Warn("gateway error detail", zap.String("type", detail.GetTypeUrl()), zap.Error(err))
+			continue
+		}
+		body.Details = append(body.Details, data)
+	}
+
+	switch st.Code() {
+	case codes.Unauthenticated:
+		w.Header().Set("WWW-Authenticate", "Bearer")
+	case codes.ResourceExhausted:
+		for _, detail := range st.Details() {
+			if retry, ok := detail.(*errdetails.RetryInfo); ok {
+				seconds := math.Ceil(retry.GetRetryDelay().AsDuration().Seconds())
+				w.Header().Set("Retry-After", strconv.Itoa(int(seconds)))
+			}
+		}
+	}
+
+	writeJSON(w, HTTPStatus(st.Code()), body)
+}
@@ -0,0 +1,310 @@
+// Package rest serves JobService as JSON over HTTP for clients that can't
+// speak gRPC. Every route calls the gRPC server through a client connection,
+// so requests go through the same interceptors, authentication, RBAC and
+// rate limits included, and the gRPC status of a failed call is mapped to
+// the HTTP response.
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	pb "fifth_exam/job_service/genproto/job_service"
+	"fifth_exam/job_service/internal/pkg/requestid"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.uber.org/zap"
+	errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// PathOpenAPI serves the OpenAPI document of the routes, without auth
+	PathOpenAPI = "/v1/openapi.json"
+
+	// maxBodyBytes bounds request bodies
+	maxBodyBytes = 1 << 20
+)
+
+type gateway struct {
+	client pb.JobServiceClient
+	logger *zap.Logger
+}
+
+// NewHandler returns the HTTP handler of the JobService routes calling
+// client, responses allow the origins of corsOrigins ("*" allows any)
+func NewHandler(client pb.JobServiceClient, logger *zap.Logger, corsOrigins []string) http.Handler {
+	g := &gateway{client: client, logger: logger}
+
+	mux := http.NewServeMux()
+	routes := g.routes()
+	for _, route := range routes {
+		pattern := route.method + " " + route.path
+		// the span is named after the route rather than the requested path
+		mux.Handle(pattern, otelhttp.NewHandler(g.handle(route), pattern))
+	}
+
+	spec, err := json.Marshal(openAPI(routes))
+	if err != nil {
+		panic(fmt.Sprintf("rest: openapi document: %v", err))
+	}
+	mux.HandleFunc(http.MethodGet+" "+PathOpenAPI, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(spec)
+	})
+
+	return cors(corsOrigins, mux)
+}
+
+// route is an HTTP endpoint of one RPC, its fields also generate the
+// OpenAPI document
+type route struct {
+	method      string
+	path        string
+	operationID string
+	summary     string
+	query       []parameter
+	// body and response are zero values of the JSON types, body is nil for
+	// routes without a request body and response for empty responses
+	body     interface{}
+	response interface{}
+	status   int
+	call     func(ctx context.Context, r *http.Request, opts ...grpc.CallOption) (interface{}, error)
+}
+
+type parameter struct {
+	name        string
+	kind        string
+	description string
+}
+
+func (g *gateway) routes() []route {
+	return []route{
+		{
+			method:      http.MethodGet,
+			path:        "/v1/jobs",
+			operationID: "ListJobs",
+			summary:     "List jobs, only the jobs of owner_id when it is set",
+			query: []parameter{
+				{name: "page", kind: "integer", description: "page number starting at 1"},
+				{name: "limit", kind: "integer", description: "jobs per page, the maximum when 0"},
+				{name: "order_by", kind: "string", description: "column the jobs are ordered by"},
+				{name: "owner_id", kind: "string", description: "id of the client owning the jobs"},
+				{name: "include_deleted", kind: "boolean", description: "include deleted jobs, with owner_id only"},
+			},
+			response: pb.Jobs{},
+			status:   http.StatusOK,
+			call:     g.list,
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/v1/jobs",
+			operationID: "CreateJob",
+			summary:     "Create a job",
+			body:        pb.Job{},
+			response:    pb.Job{},
+			status:      http.StatusCreated,
+			call: func(ctx context.Context, r *http.Request, opts ...grpc.CallOption) (interface{}, error) {
+				var job pb.Job
+				if err := decode(r, &job); err != nil {
+					return nil, err
+				}
+				return g.client.Create(ctx, &job, opts...)
+			},
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/v1/jobs/{id}",
+			operationID: "GetJob",
+			summary:     "Get a job",
+			response:    pb.Job{},
+			status:      http.StatusOK,
+			call: func(ctx context.Context, r *http.Request, opts ...grpc.CallOption) (interface{}, error) {
+				return g.client.Get(ctx, &pb.JobRequest{Field: "id", Value: r.PathValue("id")}, opts...)
+			},
+		},
+		{
+			method:      http.MethodPatch,
+			path:        "/v1/jobs/{id}",
+			operationID: "UpdateJob",
+			summary:     "Update the fields of a job present in the body",
+			body:        pb.Job{},
+			response:    pb.Job{},
+			status:      http.StatusOK,
+			call:        g.patch,
+		},
+		{
+			method:      http.MethodDelete,
+			path:        "/v1/jobs/{id}",
+			operationID: "DeleteJob",
+			summary:     "Delete a job",
+			status:      http.StatusNoContent,
+			call: func(ctx context.Context, r *http.Request, opts ...grpc.CallOption) (interface{}, error) {
+				_, err := g.client.Delete(ctx, &pb.JobRequest{Field: "id", Value: r.PathValue("id")}, opts...)
+				return nil, err
+			},
+		},
+	}
+}
+
+func (g *gateway) list(ctx context.Context, r *http.Request, opts ...grpc.CallOption) (interface{}, error) {
+	query := r.URL.Query()
+	violations := &errdetails.BadRequest{}
+	page := queryInt(query.Get("page"), "page", violations)
+	limit := queryInt(query.Get("limit"), "limit", violations)
+	includeDeleted := false
+	if raw := query.Get("include_deleted"); raw != "" {
+		var err error
+		if includeDeleted, err = strconv.ParseBool(raw); err != nil {
+			violations.FieldViolations = append(violations.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field: "include_deleted", Description: "must be true or false",
+			})
+		}
+	}
+	if len(violations.FieldViolations) != 0 {
+		st, _ := status.New(codes.InvalidArgument, codes.InvalidArgument.String()).WithDetails(violations)
+		return nil, st.Err()
+	}
+
+	if ownerID := query.Get("owner_id"); ownerID != "" {
+		return g.client.ListJobsByOwner(ctx, &pb.ListJobsByOwnerRequest{
+			OwnerId:        ownerID,
+			Page:           page,
+			Limit:          limit,
+			OrderBy:        query.Get("order_by"),
+			IncludeDeleted: includeDeleted,
+		}, opts...)
+	}
+	return g.client.GetList(ctx, &pb.GetListFilter{
+		Page:    page,
+		Limit:   limit,
+		OrderBy: query.Get("order_by"),
+	}, opts...)
+}
+
+// patch applies the fields of the body to the current job, the update RPC
+// replaces a job as a whole
+func (g *gateway) patch(ctx context.Context, r *http.Request, opts ...grpc.CallOption) (interface{}, error) {
+	id := r.PathValue("id")
+	job, err := g.client.Get(ctx, &pb.JobRequest{Field: "id", Value: id}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := decode(r, job); err != nil {
+		return nil, err
+	}
+	job.Id = id
+	return g.client.Update(ctx, job, opts...)
+}
+
+// handle calls the RPC of route with the credentials, request id and client
+// address of r and writes its response or error
+func (g *gateway) handle(route route) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		md := metadata.MD{}
+		if authorization := r.Header.Get("Authorization"); authorization != "" {
+			md.Set("authorization", authorization)
+		}
+		if id := r.Header.Get(requestid.Header); id != "" {
+			md.Set(requestid.Header, id)
+		}
+		// the address of the connection, not an X-Forwarded-For the client
+		// could forge, trusted by the server only from the gateway
+		if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+			md.Set("x-forwarded-for", host)
+		}
+		ctx := metadata.NewOutgoingContext(r.Context(), md)
+
+		var header metadata.MD
+		resp, err := route.call(ctx, r, grpc.Header(&header))
+		if ids := header.Get(requestid.Header); len(ids) != 0 {
+			w.Header().Set(requestid.Header, ids[0])
+		}
+		if err != nil {
+			g.writeError(w, err)
+			return
+		}
+
+		if route.response == nil {
+			w.WriteHeader(route.status)
+			return
+		}
+		if route.status == http.StatusCreated {
+			if job, ok := resp.(*pb.Job); ok {
+				w.Header().Set("Location", "/v1/jobs/"+job.Id)
+			}
+		}
+		writeJSON(w, route.status, resp)
+	})
+}
+
+func decode(r *http.Request, v interface{}) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		if errors.Is(err, io.EOF) {
+			return status.Error(codes.InvalidArgument, "request body is required")
+		}
+		return status.Error(codes.InvalidArgument, "invalid request body: "+err.Error())
+	}
+	return nil
+}
+
+func queryInt(raw, name string, violations *errdetails.BadRequest) int64 {
+	if raw == "" {
+		return 0
+	}
+	n, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		violations.FieldViolations = append(violations.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field: name, Description: "must be an integer",
+		})
+	}
+	return n
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// cors answers preflight requests and allows the origins given, nothing is
+// added when there are none
+func cors(origins []string, next http.Handler) http.Handler {
+	if len(origins) == 0 {
+		return next
+	}
+	allowed := make(map[string]bool, len(origins))
+	for _, origin := range origins {
+		allowed[origin] = true
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" || !(allowed[origin] || allowed["*"]) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Add("Vary", "Origin")
+		w.Header().Set("Access-Control-Expose-Headers", requestid.Header+", Location, Retry-After")
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PATCH, DELETE")
+			w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, "+requestid.Header)
+			w.Header().Set("Access-Control-Max-Age", "600")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,232 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	pb "fifth_exam/job_service/genproto/job_service"
+	"fifth_exam/job_service/internal/pkg/requestid"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	errdetails "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// jobClientStub serves jobs from memory and records the metadata of the
+// last call
+type jobClientStub struct {
+	jobs    map[string]*pb.Job
+	md      metadata.MD
+	listed  interface{}
+	limited bool
+	// getOpts are the call options of the last Get
+	getOpts []grpc.CallOption
+}
+
+func (c *jobClientStub) record(ctx context.Context, opts []grpc.CallOption) {
+	c.md, _ = metadata.FromOutgoingContext(ctx)
+	for _, opt := range opts {
+		if header, ok := opt.(grpc.HeaderCallOption); ok {
+			*header.HeaderAddr = metadata.Pairs(requestid.Header, "req-from-server")
+		}
+	}
+}
+
+func (c *jobClientStub) Create(ctx context.Context, in *pb.Job, opts ...grpc.CallOption) (*pb.Job, error) {
+	c.record(ctx, opts)
+	if in.Title == "" {
+		st, _ := status.New(codes.InvalidArgument, codes.InvalidArgument.String()).WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "title", Description: "is required"}},
+		})
+		return nil, st.Err()
+	}
+	in.Id = "job-2"
+	c.jobs[in.Id] = in
+	return in, nil
+}
+
+func (c *jobClientStub) Get(ctx context.Context, in *pb.JobRequest, opts ...grpc.CallOption) (*pb.Job, error) {
+	c.record(ctx, opts)
+	c.getOpts = opts
+	job, ok := c.jobs[in.Value]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "job not found")
+	}
+	copied := *job
+	return &copied, nil
+}
+
+func (c *jobClientStub) Update(ctx context.Context, in *pb.Job, opts ...grpc.CallOption) (*pb.Job, error) {
+	c.record(ctx, opts)
+	c.jobs[in.Id] = in
+	return in, nil
+}
+
+func (c *jobClientStub) Delete(ctx context.Context, in *pb.JobRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	c.record(ctx, opts)
+	if c.md.Get("authorization") == nil {
+		return nil, status.Error(codes.Unauthenticated, "authorization metadata is required")
+	}
+	delete(c.jobs, in.Value)
+	return &empty.Empty{}, nil
+}
+
+func (c *jobClientStub) GetList(ctx context.Context, in *pb.GetListFilter, opts ...grpc.CallOption) (*pb.Jobs, error) {
+	c.record(ctx, opts)
+	if c.limited {
+		st, _ := status.New(codes.ResourceExhausted, "rate limit exceeded").WithDetails(&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(1500 * time.Millisecond),
+		})
+		return nil, st.Err()
+	}
+	c.listed = in
+	return &pb.Jobs{Count: int64(len(c.jobs))}, nil
+}
+
+func (c *jobClientStub) ListJobsByOwner(ctx context.Context, in *pb.ListJobsByOwnerRequest, opts ...grpc.CallOption) (*pb.Jobs, error) {
+	c.record(ctx, opts)
+	c.listed = in
+	return &pb.Jobs{}, nil
+}
+
+type GatewayTestSuite struct {
+	suite.Suite
+	Client *jobClientStub
+	Server *httptest.Server
+}
+
+func (s *GatewayTestSuite) SetupTest() {
+	s.Client = &jobClientStub{jobs: map[string]*pb.Job{
+		"job-1": {Id: "job-1", Title: "Backend", OwnerId: "owner-1", Price: 100},
+	}}
+	s.Server = httptest.NewServer(NewHandler(s.Client, zap.NewNop(), []string{"https://app.example.com"}))
+	s.T().Cleanup(s.Server.Close)
+}
+
+func (s *GatewayTestSuite) do(method, path, body string, header ...string) (*http.Response, map[string]interface{}) {
+	req, err := http.NewRequest(method, s.Server.URL+path, strings.NewReader(body))
+	s.Suite.Require().NoError(err)
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	resp, err := http.DefaultClient.Do(req)
+	s.Suite.Require().NoError(err)
+	defer resp.Body.Close()
+
+	var decoded map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&decoded)
+	return resp, decoded
+}
+
+func (s *GatewayTestSuite) TestRoutes() {
+	resp, body := s.do(http.MethodGet, "/v1/jobs/job-1", "", "Authorization", "Bearer token", requestid.Header, "req-1")
+	s.Suite.Equal(http.StatusOK, resp.StatusCode)
+	s.Suite.Equal("Backend", body["title"])
+	s.Suite.Equal([]string{"Bearer token"}, s.Client.md.Get("authorization"))
+	s.Suite.Equal([]string{"req-1"}, s.Client.md.Get(requestid.Header))
+	s.Suite.Equal("req-from-server", resp.Header.Get(requestid.Header))
+
+	resp, body = s.do(http.MethodPost, "/v1/jobs", `{"title":"Frontend","owner_id":"owner-1"}`)
+	s.Suite.Equal(http.StatusCreated, resp.StatusCode)
+	s.Suite.Equal("/v1/jobs/job-2", resp.Header.Get("Location"))
+	s.Suite.Equal("job-2", body["id"])
+
+	// only the fields of the body change, the job is read with the same
+	// call options as it is updated
+	s.Client.getOpts = nil
+	resp, body = s.do(http.MethodPatch, "/v1/jobs/job-1", `{"price":250}`)
+	s.Suite.Equal(http.StatusOK, resp.StatusCode)
+	s.Suite.Equal("Backend", body["title"])
+	s.Suite.Equal(float64(250), body["price"])
+	s.Suite.NotEmpty(s.Client.getOpts)
+
+	resp, _ = s.do(http.MethodGet, "/v1/jobs?page=2&limit=10&order_by=price", "")
+	s.Suite.Equal(http.StatusOK, resp.StatusCode)
+	s.Suite.Equal(&pb.GetListFilter{Page: 2, Limit: 10, OrderBy: "price"}, s.Client.listed)
+
+	s.do(http.MethodGet, "/v1/jobs?owner_id=owner-1&include_deleted=true", "")
+	s.Suite.Equal(&pb.ListJobsByOwnerRequest{OwnerId: "owner-1", IncludeDeleted: true}, s.Client.listed)
+
+	resp, _ = s.do(http.MethodDelete, "/v1/jobs/job-1", "", "Authorization", "Bearer token")
+	s.Suite.Equal(http.StatusNoContent, resp.StatusCode)
+	s.Suite.NotContains(s.Client.jobs, "job-1")
+
+	resp, _ = s.do(http.MethodPut, "/v1/jobs/job-2", "{}")
+	s.Suite.Equal(http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func (s *GatewayTestSuite) TestErrors() {
+	resp, body := s.do(http.MethodGet, "/v1/jobs/missing", "")
+	s.Suite.Equal(http.StatusNotFound, resp.StatusCode)
+	s.Suite.Equal(float64(codes.NotFound), body["code"])
+	s.Suite.Equal("job not found", body["message"])
+
+	resp, body = s.do(http.MethodPost, "/v1/jobs", `{"owner_id":"owner-1"}`)
+	s.Suite.Equal(http.StatusBadRequest, resp.StatusCode)
+	detail := body["details"].([]interface{})[0].(map[string]interface{})
+	s.Suite.Equal("type.googleapis.com/google.rpc.BadRequest", detail["@type"])
+	s.Suite.Equal("title", detail["fieldViolations"].([]interface{})[0].(map[string]interface{})["field"])
+
+	resp, _ = s.do(http.MethodPost, "/v1/jobs", `{"title":"Frontend","salary":1}`)
+	s.Suite.Equal(http.StatusBadRequest, resp.StatusCode)
+
+	resp, _ = s.do(http.MethodGet, "/v1/jobs?page=two", "")
+	s.Suite.Equal(http.StatusBadRequest, resp.StatusCode)
+
+	resp, _ = s.do(http.MethodDelete, "/v1/jobs/job-1", "")
+	s.Suite.Equal(http.StatusUnauthorized, resp.StatusCode)
+	s.Suite.Equal("Bearer", resp.Header.Get("WWW-Authenticate"))
+
+	s.Client.limited = true
+	resp, _ = s.do(http.MethodGet, "/v1/jobs", "")
+	s.Suite.Equal(http.StatusTooManyRequests, resp.StatusCode)
+	s.Suite.Equal("2", resp.Header.Get("Retry-After"))
+}
+
+func (s *GatewayTestSuite) TestCORS() {
+	resp, _ := s.do(http.MethodOptions, "/v1/jobs", "", "Origin", "https://app.example.com", "Access-Control-Request-Method", "POST")
+	s.Suite.Equal(http.StatusNoContent, resp.StatusCode)
+	s.Suite.Equal("https://app.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+
+	resp, _ = s.do(http.MethodGet, "/v1/jobs/job-1", "", "Origin", "https://evil.example.com")
+	s.Suite.Empty(resp.Header.Get("Access-Control-Allow-Origin"))
+}
+
+func (s *GatewayTestSuite) TestOpenAPI() {
+	resp, spec := s.do(http.MethodGet, PathOpenAPI, "")
+	s.Suite.Equal(http.StatusOK, resp.StatusCode)
+
+	paths := spec["paths"].(map[string]interface{})
+	s.Suite.ElementsMatch([]string{"get", "post"}, keys(paths["/v1/jobs"]))
+	s.Suite.ElementsMatch([]string{"get", "patch", "delete"}, keys(paths["/v1/jobs/{id}"]))
+
+	schemas := spec["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	job := schemas["Job"].(map[string]interface{})["properties"].(map[string]interface{})
+	s.Suite.Equal("number", job["price"].(map[string]interface{})["type"])
+	s.Suite.Contains(job, "owner_id")
+	s.Suite.NotContains(job, "XXX_unrecognized")
+	s.Suite.Contains(schemas, "Jobs")
+	s.Suite.Contains(schemas, "Status")
+}
+
+func keys(value interface{}) []string {
+	var names []string
+	for name := range value.(map[string]interface{}) {
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestGatewayTestSuite(t *testing.T) {
+	suite.Run(t, new(GatewayTestSuite))
+}
@@ -0,0 +1,157 @@
+package rest
+
+import (
+	"encoding/json"
+	"fifth_exam/job_service/internal/pkg/buildinfo"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// openAPI generates the OpenAPI 3 document of routes, the schemas are
+// derived from the JSON fields of the protobuf messages so that they follow
+// the generated code
+func openAPI(routes []route) map[string]interface{} {
+	schemas := map[string]interface{}{}
+	paths := map[string]map[string]interface{}{}
+
+	for _, route := range routes {
+		operation := map[string]interface{}{
+			"operationId": route.operationID,
+			"summary":     route.summary,
+			"tags":        []string{"JobService"},
+		}
+
+		var parameters []map[string]interface{}
+		for _, name := range pathParameters(route.path) {
+			parameters = append(parameters, map[string]interface{}{
+				"name":     name,
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]string{"type": "string"},
+			})
+		}
+		for _, p := range route.query {
+			parameters = append(parameters, map[string]interface{}{
+				"name":        p.name,
+				"in":          "query",
+				"description": p.description,
+				"schema":      map[string]string{"type": p.kind},
+			})
+		}
+		if len(parameters) != 0 {
+			operation["parameters"] = parameters
+		}
+
+		if route.body != nil {
+			operation["requestBody"] = map[string]interface{}{
+				"required": true,
+				"content":  jsonContent(schemaRef(reflect.TypeOf(route.body), schemas)),
+			}
+		}
+
+		success := map[string]interface{}{"description": http.StatusText(route.status)}
+		if route.response != nil {
+			success["content"] = jsonContent(schemaRef(reflect.TypeOf(route.response), schemas))
+		}
+		operation["responses"] = map[string]interface{}{
+			strconv.Itoa(route.status): success,
+			"default": map[string]interface{}{
+				"description": "gRPC status of the failed call",
+				"content":     jsonContent(schemaRef(reflect.TypeOf(errorBody{}), schemas)),
+			},
+		}
+
+		if paths[route.path] == nil {
+			paths[route.path] = map[string]interface{}{}
+		}
+		paths[route.path][strings.ToLower(route.method)] = operation
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]string{
+			"title":   "JobService",
+			"version": buildinfo.Get().Version,
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+			"securitySchemes": map[string]interface{}{
+				"bearerAuth": map[string]string{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			},
+		},
+		"security": []map[string][]string{{"bearerAuth": {}}},
+	}
+}
+
+func jsonContent(schema interface{}) map[string]interface{} {
+	return map[string]interface{}{"application/json": map[string]interface{}{"schema": schema}}
+}
+
+func pathParameters(path string) []string {
+	var names []string
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			names = append(names, strings.Trim(segment, "{}"))
+		}
+	}
+	return names
+}
+
+// schemaRef returns the schema of t, structs are added to schemas and
+// referenced by name
+func schemaRef(t reflect.Type, schemas map[string]interface{}) interface{} {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == reflect.TypeOf(json.RawMessage{}) {
+		return map[string]string{"type": "object"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]string{"type": "string"}
+	case reflect.Bool:
+		return map[string]string{"type": "boolean"}
+	case reflect.Int32, reflect.Uint32:
+		return map[string]string{"type": "integer", "format": "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint64:
+		return map[string]string{"type": "integer", "format": "int64"}
+	case reflect.Float32:
+		return map[string]string{"type": "number", "format": "float"}
+	case reflect.Float64:
+		return map[string]string{"type": "number", "format": "double"}
+	case reflect.Slice:
+		return map[string]interface{}{"type": "array", "items": schemaRef(t.Elem(), schemas)}
+	case reflect.Struct:
+		name := schemaName(t)
+		if _, ok := schemas[name]; !ok {
+			// registered before the fields for recursive messages
+			schemas[name] = nil
+			properties := map[string]interface{}{}
+			for i := 0; i < t.NumField(); i++ {
+				field := t.Field(i)
+				tag := strings.Split(field.Tag.Get("json"), ",")[0]
+				if !field.IsExported() || tag == "-" || strings.HasPrefix(field.Name, "XXX_") {
+					continue
+				}
+				if tag == "" {
+					tag = field.Name
+				}
+				properties[tag] = schemaRef(field.Type, schemas)
+			}
+			schemas[name] = map[string]interface{}{"type": "object", "properties": properties}
+		}
+		return map[string]string{"$ref": "#/components/schemas/" + name}
+	}
+	return map[string]string{}
+}
+
+func schemaName(t reflect.Type) string {
+	if t == reflect.TypeOf(errorBody{}) {
+		return "Status"
+	}
+	return t.Name()
+}
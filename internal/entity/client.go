@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+type Client struct {
+	Id        string
+	Username  string
+	Email     string
+	Phone     string
+	Address   string
+	IsActive  bool
+	IsDeleted bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt time.Time
+}
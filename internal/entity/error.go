@@ -36,6 +36,32 @@ func NewErrConflict(text string) *ErrConflict {
 	return &ErrConflict{text}
 }
 
+// error failed precondition, the object is not in a state allowing the operation
+type ErrFailedPrecondition struct {
+	reason string
+}
+
+func (e *ErrFailedPrecondition) Error() string {
+	return e.reason
+}
+
+func NewErrFailedPrecondition(reason string) *ErrFailedPrecondition {
+	return &ErrFailedPrecondition{reason}
+}
+
+// error permission denied, the caller may not act on the object
+type ErrPermissionDenied struct {
+	reason string
+}
+
+func (e *ErrPermissionDenied) Error() string {
+	return e.reason
+}
+
+func NewErrPermissionDenied(reason string) *ErrPermissionDenied {
+	return &ErrPermissionDenied{reason}
+}
+
 // error validation
 type ErrValidation struct {
 	Err    error
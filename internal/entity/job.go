@@ -13,6 +13,33 @@ type Job struct {
 	CreatedAt   time.Time `protobuf:"6"`
 	UpdatedAt   time.Time `protobuf:"7"`
 	DeletedAt   time.Time `protobuf:"8"`
+	CancelledAt time.Time
+}
+
+const (
+	// JobDateLayout is the layout of FromDate and ToDate
+	JobDateLayout = "2006-01-02"
+
+	JobStatusOpen       = "open"
+	JobStatusInProgress = "in_progress"
+	JobStatusFinished   = "finished"
+	JobStatusCancelled  = "cancelled"
+)
+
+// Status derives the state of the job at now from its dates, a job is open
+// until FromDate and finished after ToDate
+func (j *Job) Status(now time.Time) string {
+	today := now.Format(JobDateLayout)
+	switch {
+	case !j.CancelledAt.IsZero():
+		return JobStatusCancelled
+	case j.FromDate > today:
+		return JobStatusOpen
+	case j.ToDate < today:
+		return JobStatusFinished
+	default:
+		return JobStatusInProgress
+	}
 }
 
 type GetListFilter struct {
@@ -21,4 +48,5 @@ type GetListFilter struct {
 	Search         string `json:"search" protobuf:"3"`
 	OrderBy        string `json:"order_by" protobuf:"4"`
 	IncludeDeleted bool   `json:"include_deleted" protobuf:"5"`
+	OwnerId        string `json:"owner_id"`
 }
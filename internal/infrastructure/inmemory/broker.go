@@ -0,0 +1,425 @@
+// Package inmemory provides an in-process message broker implementing
+// event.BrokerConsumer and event.BrokerProducer for tests and local development
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"fifth_exam/job_service/internal/usecase/event"
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+var ErrBrokerClosed = errors.New("in-memory broker is closed")
+
+// a failed message is handled again after retryBackoff, doubled on every
+// failure up to maxRetryBackoff, shorter than the kafka consumer's as the
+// broker serves tests
+const (
+	retryBackoff    = 10 * time.Millisecond
+	maxRetryBackoff = 200 * time.Millisecond
+)
+
+type Message struct {
+	Topic     string
+	Partition int
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Time      time.Time
+}
+
+type claimKey struct {
+	group     string
+	topic     string
+	partition int
+}
+
+// Broker keeps topics as partitioned append-only logs and the committed offset
+// of every consumer group, like kafka a partition is delivered to only one
+// consumer of a group at a time
+type Broker struct {
+	mu         sync.Mutex
+	partitions int
+	closed     bool
+	next       int
+	topics     map[string][][]Message
+	committed  map[string]map[string][]int64
+	claims     map[claimKey]*consumer
+	// notify is closed and replaced whenever a message is produced or an
+	// offset is committed
+	notify chan struct{}
+}
+
+// NewBroker creates a broker whose topics have the given number of partitions
+func NewBroker(partitions int) *Broker {
+	if partitions < 1 {
+		partitions = 1
+	}
+	return &Broker{
+		partitions: partitions,
+		topics:     make(map[string][][]Message),
+		committed:  make(map[string]map[string][]int64),
+		claims:     make(map[claimKey]*consumer),
+		notify:     make(chan struct{}),
+	}
+}
+
+// Produce appends a message to the topic, messages with the same key always
+// land on the same partition and keyless messages are spread round robin
+func (b *Broker) Produce(ctx context.Context, topic string, key, value []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrBrokerClosed
+	}
+
+	partitions := b.topic(topic)
+
+	partition := b.next % b.partitions
+	b.next++
+	if len(key) != 0 {
+		h := fnv.New32a()
+		h.Write(key)
+		partition = int(h.Sum32() % uint32(b.partitions))
+	}
+
+	partitions[partition] = append(partitions[partition], Message{
+		Topic:     topic,
+		Partition: partition,
+		Offset:    int64(len(partitions[partition])),
+		Key:       key,
+		Value:     value,
+		Time:      time.Now(),
+	})
+	b.broadcast()
+
+	return nil
+}
+
+// Close stops delivering messages to every consumer of the broker
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	b.broadcast()
+}
+
+// Consumer returns a new member able to join consumer groups of the broker
+func (b *Broker) Consumer() event.BrokerConsumer {
+	return &consumer{broker: b}
+}
+
+// Messages returns every message of the topic ordered by partition and offset
+func (b *Broker) Messages(topic string) []Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var messages []Message
+	for _, partition := range b.topics[topic] {
+		messages = append(messages, partition...)
+	}
+	return messages
+}
+
+// Committed returns the next offset the group will consume per partition
+func (b *Broker) Committed(group, topic string) []int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]int64(nil), b.offsets(group, topic)...)
+}
+
+// Lag returns the number of messages of the topic not yet committed by the group
+func (b *Broker) Lag(group, topic string) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.lag(group, topic)
+}
+
+// SetOffset moves the committed offset of the group on one partition, the
+// new position is used the next time the partition is claimed
+func (b *Broker) SetOffset(group, topic string, partition int, offset int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if partition < 0 || partition >= b.partitions {
+		return
+	}
+	b.offsets(group, topic)[partition] = offset
+	b.broadcast()
+}
+
+// WaitCommitted blocks until the group committed every message of the topic
+func (b *Broker) WaitCommitted(ctx context.Context, group, topic string) error {
+	for {
+		b.mu.Lock()
+		lag, notify := b.lag(group, topic), b.notify
+		b.mu.Unlock()
+
+		if lag == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-notify:
+		}
+	}
+}
+
+func (b *Broker) topic(name string) [][]Message {
+	partitions, ok := b.topics[name]
+	if !ok {
+		partitions = make([][]Message, b.partitions)
+		b.topics[name] = partitions
+	}
+	return partitions
+}
+
+func (b *Broker) offsets(group, topic string) []int64 {
+	topics, ok := b.committed[group]
+	if !ok {
+		topics = make(map[string][]int64)
+		b.committed[group] = topics
+	}
+	offsets, ok := topics[topic]
+	if !ok {
+		offsets = make([]int64, b.partitions)
+		topics[topic] = offsets
+	}
+	return offsets
+}
+
+func (b *Broker) lag(group, topic string) int64 {
+	var lag int64
+	offsets := b.offsets(group, topic)
+	for partition, messages := range b.topic(topic) {
+		lag += int64(len(messages)) - offsets[partition]
+	}
+	return lag
+}
+
+func (b *Broker) broadcast() {
+	close(b.notify)
+	b.notify = make(chan struct{})
+}
+
+func (b *Broker) claim(key claimKey, c *consumer) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, taken := b.claims[key]; taken {
+		return false
+	}
+	b.claims[key] = c
+	return true
+}
+
+func (b *Broker) release(c *consumer) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for key, owner := range b.claims {
+		if owner == c {
+			delete(b.claims, key)
+		}
+	}
+}
+
+// fetch waits for the message at offset, ok is false once ctx is done or the
+// broker is closed
+func (b *Broker) fetch(ctx context.Context, key claimKey, offset int64) (msg Message, ok bool) {
+	for {
+		b.mu.Lock()
+		if b.closed {
+			b.mu.Unlock()
+			return Message{}, false
+		}
+		partition := b.topic(key.topic)[key.partition]
+		if offset < int64(len(partition)) {
+			msg = partition[offset]
+			b.mu.Unlock()
+			return msg, true
+		}
+		notify := b.notify
+		b.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return Message{}, false
+		case <-notify:
+		}
+	}
+}
+
+func (b *Broker) commit(key claimKey, offset int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	offsets := b.offsets(key.group, key.topic)
+	if offset > offsets[key.partition] {
+		offsets[key.partition] = offset
+	}
+	b.broadcast()
+}
+
+type consumer struct {
+	broker  *Broker
+	configs []event.ConsumerConfig
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+
+	mu       sync.Mutex
+	statuses []event.ConsumerStatus
+}
+
+func (c *consumer) RegisterConsumer(config event.ConsumerConfig) {
+	c.configs = append(c.configs, config)
+
+	c.mu.Lock()
+	c.statuses = append(c.statuses, event.ConsumerStatus{
+		Topic:   config.GetTopic(),
+		GroupID: config.GetGroupID(),
+		Brokers: config.GetBrokers(),
+	})
+	c.mu.Unlock()
+}
+
+func (c *consumer) Consumers() []event.ConsumerStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	statuses := make([]event.ConsumerStatus, len(c.statuses))
+	copy(statuses, c.statuses)
+	return statuses
+}
+
+// observe counts a delivered message in the status of the consumer of key
+func (c *consumer) observe(key claimKey, msg Message, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.statuses {
+		status := &c.statuses[i]
+		if status.Topic != key.topic || status.GroupID != key.group {
+			continue
+		}
+		switch {
+		case event.Skipped(err):
+			status.Skipped++
+		case err != nil:
+			status.Failed++
+		default:
+			status.Handled++
+		}
+		status.LastOffset = msg.Offset
+		status.LastMessageAt = time.Now()
+		return
+	}
+}
+
+func (c *consumer) setRunning(running bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.statuses {
+		c.statuses[i].Running = running
+	}
+}
+
+// Run claims the free partitions of every registered topic and delivers
+// their messages from the committed offset of the group. As with the kafka
+// consumer a message is committed once its handler succeeds or skips it, see
+// event.Skip. A message whose handler fails otherwise is handled again, with
+// backoff, until it succeeds and nothing after it is delivered in the
+// meantime. When the consumer is closed first the message stays uncommitted
+// and is delivered again the next time the partition is claimed.
+func (c *consumer) Run() error {
+	c.broker.mu.Lock()
+	closed := c.broker.closed
+	c.broker.mu.Unlock()
+	if closed {
+		return ErrBrokerClosed
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.setRunning(true)
+
+	for _, config := range c.configs {
+		for partition := 0; partition < c.broker.partitions; partition++ {
+			key := claimKey{group: config.GetGroupID(), topic: config.GetTopic(), partition: partition}
+			if !c.broker.claim(key, c) {
+				continue
+			}
+
+			c.wg.Add(1)
+			go c.deliver(ctx, key, config.GetHandler())
+		}
+	}
+
+	return nil
+}
+
+func (c *consumer) Close() {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+	c.setRunning(false)
+	c.broker.release(c)
+}
+
+func (c *consumer) deliver(ctx context.Context, key claimKey, handler func(ctx context.Context, key, value []byte) error) {
+	defer c.wg.Done()
+
+	c.broker.mu.Lock()
+	offset := c.broker.offsets(key.group, key.topic)[key.partition]
+	c.broker.mu.Unlock()
+
+	for {
+		msg, ok := c.broker.fetch(ctx, key, offset)
+		if !ok {
+			return
+		}
+		if !c.handleUntilDone(ctx, key, msg, handler) {
+			return
+		}
+
+		offset = msg.Offset + 1
+		c.broker.commit(key, offset)
+	}
+}
+
+// handleUntilDone handles msg, again after a backoff every time it fails
+// unless it is skipped, and reports false when ctx is done before it
+// succeeds
+func (c *consumer) handleUntilDone(ctx context.Context, key claimKey, msg Message, handler func(ctx context.Context, key, value []byte) error) bool {
+	backoff := retryBackoff
+	for {
+		err := handler(ctx, msg.Key, msg.Value)
+		c.observe(key, msg, err)
+		if err == nil || event.Skipped(err) {
+			return true
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"fifth_exam/job_service/internal/infrastructure/kafka"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const testTopic = "job.service.create"
+
+type BrokerTestSuite struct {
+	suite.Suite
+	Broker *Broker
+}
+
+func (s *BrokerTestSuite) SetupTest() {
+	s.Broker = NewBroker(3)
+}
+
+func (s *BrokerTestSuite) TearDownTest() {
+	s.Broker.Close()
+}
+
+func (s *BrokerTestSuite) TestKeyedMessagesKeepPartition() {
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		s.Suite.NoError(s.Broker.Produce(ctx, testTopic, []byte("owner"), []byte("value")))
+	}
+
+	messages := s.Broker.Messages(testTopic)
+	s.Suite.Len(messages, 5)
+	for i, msg := range messages {
+		s.Suite.Equal(messages[0].Partition, msg.Partition)
+		s.Suite.Equal(int64(i), msg.Offset)
+	}
+}
+
+func (s *BrokerTestSuite) TestGroupConsumesAndCommits() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		received []string
+	)
+	consumer := s.Broker.Consumer()
+	consumer.RegisterConsumer(kafka.NewConsumerConfig(nil, testTopic, "group", func(ctx context.Context, key, value []byte) error {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, string(value))
+		return nil
+	}))
+	s.Suite.NoError(consumer.Run())
+
+	for _, value := range []string{"a", "b", "c", "d"} {
+		s.Suite.NoError(s.Broker.Produce(ctx, testTopic, nil, []byte(value)))
+	}
+
+	s.Suite.NoError(s.Broker.WaitCommitted(ctx, "group", testTopic))
+	consumer.Close()
+
+	mu.Lock()
+	s.Suite.ElementsMatch([]string{"a", "b", "c", "d"}, received)
+	mu.Unlock()
+	s.Suite.Equal(int64(0), s.Broker.Lag("group", testTopic))
+	s.Suite.Equal(int64(4), s.Broker.Lag("other", testTopic))
+}
+
+func (s *BrokerTestSuite) TestFailedMessageIsRedelivered() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	s.Suite.NoError(s.Broker.Produce(ctx, testTopic, []byte("key"), []byte("value")))
+
+	attempts := make(chan struct{}, 1)
+	failing := s.Broker.Consumer()
+	failing.RegisterConsumer(kafka.NewConsumerConfig(nil, testTopic, "group", func(ctx context.Context, key, value []byte) error {
+		select {
+		case attempts <- struct{}{}:
+		default:
+		}
+		return errors.New("handler failed")
+	}))
+	s.Suite.NoError(failing.Run())
+	<-attempts
+	failing.Close()
+	s.Suite.Equal(int64(1), s.Broker.Lag("group", testTopic))
+
+	succeeding := s.Broker.Consumer()
+	succeeding.RegisterConsumer(kafka.NewConsumerConfig(nil, testTopic, "group", func(ctx context.Context, key, value []byte) error {
+		return nil
+	}))
+	s.Suite.NoError(succeeding.Run())
+	defer succeeding.Close()
+
+	s.Suite.NoError(s.Broker.WaitCommitted(ctx, "group", testTopic))
+}
+
+func (s *BrokerTestSuite) TestFailedMessageIsRetriedBeforeLaterOnes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// the same key keeps both messages in one partition
+	s.Suite.NoError(s.Broker.Produce(ctx, testTopic, []byte("key"), []byte("fail")))
+	s.Suite.NoError(s.Broker.Produce(ctx, testTopic, []byte("key"), []byte("ok")))
+	partition := s.Broker.Messages(testTopic)[0].Partition
+
+	var (
+		mu        sync.Mutex
+		handled   []string
+		committed []int64
+		failures  int
+	)
+	consumer := s.Broker.Consumer()
+	consumer.RegisterConsumer(kafka.NewConsumerConfig(nil, testTopic, "group", func(ctx context.Context, key, value []byte) error {
+		mu.Lock()
+		defer mu.Unlock()
+		handled = append(handled, string(value))
+		committed = append(committed, s.Broker.Committed("group", testTopic)[partition])
+		if string(value) == "fail" && failures < 2 {
+			failures++
+			return errors.New("handler failed")
+		}
+		return nil
+	}))
+	s.Suite.NoError(consumer.Run())
+
+	s.Suite.NoError(s.Broker.WaitCommitted(ctx, "group", testTopic))
+	consumer.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	s.Suite.Equal([]string{"fail", "fail", "fail", "ok"}, handled)
+	// nothing is committed until the failed message succeeds
+	s.Suite.Equal([]int64{0, 0, 0, 1}, committed)
+	s.Suite.Equal(int64(2), s.Broker.Committed("group", testTopic)[partition])
+
+	statuses := consumer.Consumers()
+	s.Suite.Equal(int64(2), statuses[0].Handled)
+	s.Suite.Equal(int64(2), statuses[0].Failed)
+}
+
+func TestBrokerTestSuite(t *testing.T) {
+	suite.Run(t, new(BrokerTestSuite))
+}
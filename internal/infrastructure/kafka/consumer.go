@@ -4,7 +4,10 @@ import (
 	"context"
 	"fifth_exam/job_service/internal/usecase/event"
 	"fmt"
+	"sync"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/segmentio/kafka-go"
 	"go.uber.org/zap"
 )
@@ -12,28 +15,85 @@ import (
 const (
 	MinBytes = 10e3 // 10KB
 	MaxBytes = 10e6 // 10MB
+
+	// a failed message is handled again after retryBackoff, doubled on every
+	// failure up to maxRetryBackoff
+	retryBackoff    = 500 * time.Millisecond
+	maxRetryBackoff = 30 * time.Second
 )
 
 type HandlerFunc func(ctx context.Context, key, value []byte) error
 
+// messageReader is the part of kafka.Reader a consumer reads a group with
+type messageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
 type consumer struct {
 	logger          *zap.Logger
 	consumerConfigs []event.ConsumerConfig
 	readers         []*kafka.Reader
+	metrics         *consumerMetrics
+
+	// retries of failed messages wait backoff, up to maxBackoff, and stop
+	// when done is closed
+	backoff    time.Duration
+	maxBackoff time.Duration
+	done       chan struct{}
+	closeOnce  sync.Once
+
+	// statuses follow consumerConfigs
+	mu       sync.Mutex
+	statuses []event.ConsumerStatus
 }
 
-func NewConsumer(logger *zap.Logger) *consumer {
-	return &consumer{
-		logger: logger,
+// NewConsumer creates a broker consumer, lag, throughput and handler metrics
+// of its readers are registered in registerer when it is not nil
+func NewConsumer(logger *zap.Logger, registerer prometheus.Registerer) *consumer {
+	c := &consumer{
+		logger:     logger,
+		metrics:    newConsumerMetrics(),
+		backoff:    retryBackoff,
+		maxBackoff: maxRetryBackoff,
+		done:       make(chan struct{}),
+	}
+	if registerer != nil {
+		registerer.MustRegister(c.metrics)
 	}
+	return c
 }
 
 func (c *consumer) RegisterConsumer(consumerConfig event.ConsumerConfig) {
 	c.consumerConfigs = append(c.consumerConfigs, consumerConfig)
+
+	c.mu.Lock()
+	c.statuses = append(c.statuses, event.ConsumerStatus{
+		Topic:   consumerConfig.GetTopic(),
+		GroupID: consumerConfig.GetGroupID(),
+		Brokers: consumerConfig.GetBrokers(),
+	})
+	c.mu.Unlock()
+}
+
+func (c *consumer) Consumers() []event.ConsumerStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	statuses := make([]event.ConsumerStatus, len(c.statuses))
+	copy(statuses, c.statuses)
+	return statuses
+}
+
+// updateStatus applies fn to the status of the i-th registered consumer
+func (c *consumer) updateStatus(i int, fn func(status *event.ConsumerStatus)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fn(&c.statuses[i])
 }
 
 func (c *consumer) Run() error {
-	for _, consumerConfig := range c.consumerConfigs {
+	for i, consumerConfig := range c.consumerConfigs {
 		r := kafka.NewReader(kafka.ReaderConfig{
 			Brokers:  consumerConfig.GetBrokers(),
 			Topic:    consumerConfig.GetTopic(),
@@ -42,13 +102,16 @@ func (c *consumer) Run() error {
 			MaxBytes: MaxBytes,
 		})
 		c.readers = append(c.readers, r)
-		go runReader(r, consumerConfig, c.logger)
+		c.metrics.addReader(r, consumerConfig.GetGroupID())
+		c.updateStatus(i, func(status *event.ConsumerStatus) { status.Running = true })
+		go c.runReader(r, i, consumerConfig)
 	}
 
 	return nil
 }
 
 func (c *consumer) Close() {
+	c.closeOnce.Do(func() { close(c.done) })
 	for _, reader := range c.readers {
 		if err := reader.Close(); err != nil {
 			c.logger.Error("consumer reader close", zap.Error(err))
@@ -56,26 +119,89 @@ func (c *consumer) Close() {
 	}
 }
 
-func runReader(r *kafka.Reader, consumerConfig event.ConsumerConfig, logger *zap.Logger) {
+// runReader handles the messages of r in order and commits each one once it
+// is handled or skipped, see event.Skip. A message that fails otherwise is
+// handled again, with backoff, until it succeeds so that no later message
+// commits the group offset past it, the reader stops consuming in the
+// meantime. When the consumer is closed during the retries the message stays
+// uncommitted and is fetched again by the next member of the group.
+func (c *consumer) runReader(r messageReader, i int, consumerConfig event.ConsumerConfig) {
 	var (
 		topic   = consumerConfig.GetTopic()
+		group   = consumerConfig.GetGroupID()
 		handler = consumerConfig.GetHandler()
 	)
+	defer c.updateStatus(i, func(status *event.ConsumerStatus) { status.Running = false })
+
 	for {
 		ctx := context.Background()
 		m, err := r.FetchMessage(ctx)
 		if err != nil {
-			logger.Error("consumer failed to fetch message:", zap.String("topic", topic), zap.Error(err))
+			c.metrics.observeError(topic, group, stageFetch)
+			c.logger.Error("consumer failed to fetch message:", zap.String("topic", topic), zap.Error(err))
 			break
 		}
+		c.metrics.observeFetch(m, group)
 
-		if err := handler(ctx, m.Key, m.Value); err != nil {
-			logger.Error("consumer failed to handle message:", zap.ByteString("value", m.Value), zap.String("topic", topic), zap.Error(err))
-			continue
+		if !c.handleUntilDone(m, i, group, handler) {
+			return
 		}
 
 		if err := r.CommitMessages(ctx, m); err != nil {
-			logger.Error("consumer failed to commit messages:", zap.String("topic", topic), zap.Error(err))
+			c.metrics.observeError(topic, group, stageCommit)
+			c.logger.Error("consumer failed to commit messages:", zap.String("topic", topic), zap.Error(err))
+		}
+	}
+}
+
+// handleUntilDone handles m until it succeeds or is skipped and reports
+// false when the consumer was closed before
+func (c *consumer) handleUntilDone(m kafka.Message, i int, group string, handler HandlerFunc) bool {
+	backoff := c.backoff
+	for {
+		started := time.Now()
+		err := c.handle(m, group, handler)
+		c.metrics.observeHandle(m, group, started, err)
+		skipped := event.Skipped(err)
+		c.updateStatus(i, func(status *event.ConsumerStatus) {
+			switch {
+			case skipped:
+				status.Skipped++
+			case err != nil:
+				status.Failed++
+			default:
+				status.Handled++
+			}
+			status.LastOffset = m.Offset
+			status.LastMessageAt = m.Time
+		})
+		if skipped {
+			c.logger.Warn("consumer skipped message:",
+				zap.ByteString("value", m.Value),
+				zap.String("topic", m.Topic),
+				zap.Int("partition", m.Partition),
+				zap.Int64("offset", m.Offset),
+				zap.Error(err))
+			return true
+		}
+		if err == nil {
+			return true
+		}
+		c.logger.Error("consumer failed to handle message, retrying:",
+			zap.ByteString("value", m.Value),
+			zap.String("topic", m.Topic),
+			zap.Int("partition", m.Partition),
+			zap.Int64("offset", m.Offset),
+			zap.Duration("backoff", backoff),
+			zap.Error(err))
+
+		select {
+		case <-c.done:
+			return false
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > c.maxBackoff {
+			backoff = c.maxBackoff
 		}
 	}
 }
@@ -93,7 +219,6 @@ func NewConsumerConfig(
 	groupID string,
 	handler HandlerFunc,
 ) *ConsumerConfig {
-	fmt.Println("New consumer config")
 	return &ConsumerConfig{
 		brokers: brokers,
 		topic:   topic,
@@ -117,3 +242,20 @@ func (c *ConsumerConfig) GetGroupID() string {
 func (c *ConsumerConfig) GetHandler() func(ctx context.Context, key, value []byte) error {
 	return c.handler
 }
+
+// Ping succeeds when at least one of the brokers accepts a connection
+func Ping(ctx context.Context, brokers []string) error {
+	var lastErr error
+	for _, broker := range brokers {
+		conn, err := kafka.DialContext(ctx, "tcp", broker)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return conn.Close()
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no kafka brokers configured")
+	}
+	return lastErr
+}
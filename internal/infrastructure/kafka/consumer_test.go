@@ -0,0 +1,179 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fifth_exam/job_service/internal/pkg/metrics"
+	"fifth_exam/job_service/internal/usecase/event"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+const (
+	testTopic = "job.service.create"
+	testGroup = "job_service"
+)
+
+// readerStub serves its messages once and then fails with io.EOF like a
+// closed kafka.Reader
+type readerStub struct {
+	messages  []kafka.Message
+	committed []int64
+}
+
+func (r *readerStub) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	if len(r.messages) == 0 {
+		return kafka.Message{}, io.EOF
+	}
+	m := r.messages[0]
+	r.messages = r.messages[1:]
+	return m, nil
+}
+
+func (r *readerStub) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+	for _, m := range msgs {
+		r.committed = append(r.committed, m.Offset)
+	}
+	return nil
+}
+
+type ConsumerTestSuite struct {
+	suite.Suite
+	Registry *prometheus.Registry
+	Consumer *consumer
+}
+
+func (s *ConsumerTestSuite) SetupTest() {
+	s.Registry = prometheus.NewRegistry()
+	s.Consumer = NewConsumer(zap.NewNop(), s.Registry)
+}
+
+func (s *ConsumerTestSuite) TestFailedMessageIsRetriedBeforeLaterOnesAreCommitted() {
+	errHandler := errors.New("postgres is down")
+	s.Consumer.backoff, s.Consumer.maxBackoff = time.Millisecond, 2*time.Millisecond
+
+	reader := &readerStub{}
+	// handled are the values in the order they were handled and committed
+	// the offsets committed when each of them was handled
+	var (
+		handled   []string
+		committed [][]int64
+		failures  int
+	)
+	config := NewConsumerConfig(nil, testTopic, testGroup, func(ctx context.Context, key, value []byte) error {
+		handled = append(handled, string(value))
+		committed = append(committed, append([]int64(nil), reader.committed...))
+		if string(value) == "fail" && failures < 3 {
+			failures++
+			time.Sleep(5 * time.Millisecond)
+			return errHandler
+		}
+		return nil
+	})
+	s.Consumer.RegisterConsumer(config)
+
+	for offset, value := range []string{"ok", "fail", "ok"} {
+		reader.messages = append(reader.messages, kafka.Message{
+			Topic:         testTopic,
+			Partition:     1,
+			Offset:        int64(offset),
+			HighWaterMark: 3,
+			Value:         []byte(value),
+		})
+	}
+
+	s.Consumer.runReader(reader, 0, config)
+
+	// the failed message is handled again until it succeeds and nothing after
+	// it is handled or committed before
+	s.Suite.Equal([]string{"ok", "fail", "fail", "fail", "fail", "ok"}, handled)
+	s.Suite.Equal([][]int64{nil, {0}, {0}, {0}, {0}, {0, 1}}, committed)
+	s.Suite.Equal([]int64{0, 1, 2}, reader.committed)
+
+	m := s.Consumer.metrics
+	s.Suite.Equal(3.0, testutil.ToFloat64(m.errors.WithLabelValues(testTopic, testGroup, stageHandle)))
+	s.Suite.Equal(1.0, testutil.ToFloat64(m.errors.WithLabelValues(testTopic, testGroup, stageFetch)))
+	s.Suite.Equal(0.0, testutil.ToFloat64(m.errors.WithLabelValues(testTopic, testGroup, stageCommit)))
+	s.Suite.Equal(3.0, testutil.ToFloat64(m.processed.WithLabelValues(testTopic, "1", testGroup, statusSuccess)))
+	s.Suite.Equal(3.0, testutil.ToFloat64(m.processed.WithLabelValues(testTopic, "1", testGroup, statusError)))
+
+	// every attempt is timed whatever its result
+	families, err := s.Registry.Gather()
+	s.Suite.NoError(err)
+	found := false
+	for _, family := range families {
+		if family.GetName() != metrics.Namespace+"_"+metricsSubsystem+"_handler_duration_seconds" {
+			continue
+		}
+		found = true
+		s.Suite.Len(family.GetMetric(), 1)
+		h := family.GetMetric()[0].GetHistogram()
+		s.Suite.Equal(uint64(6), h.GetSampleCount())
+		s.Suite.GreaterOrEqual(h.GetSampleSum(), (15 * time.Millisecond).Seconds())
+	}
+	s.Suite.True(found, "handler duration histogram is registered")
+
+	statuses := s.Consumer.Consumers()
+	s.Suite.Len(statuses, 1)
+	s.Suite.Equal(int64(3), statuses[0].Handled)
+	s.Suite.Equal(int64(3), statuses[0].Failed)
+	s.Suite.False(statuses[0].Running)
+}
+
+func (s *ConsumerTestSuite) TestSkippedMessageIsCommitted() {
+	reader := &readerStub{messages: []kafka.Message{
+		{Topic: testTopic, Partition: 1, Offset: 0, Value: []byte("invalid")},
+		{Topic: testTopic, Partition: 1, Offset: 1, Value: []byte("ok")},
+	}}
+	config := NewConsumerConfig(nil, testTopic, testGroup, func(ctx context.Context, key, value []byte) error {
+		if string(value) == "invalid" {
+			return event.Skip(errors.New("invalid job message"))
+		}
+		return nil
+	})
+	s.Consumer.RegisterConsumer(config)
+
+	s.Consumer.runReader(reader, 0, config)
+
+	s.Suite.Equal([]int64{0, 1}, reader.committed)
+	m := s.Consumer.metrics
+	s.Suite.Equal(1.0, testutil.ToFloat64(m.processed.WithLabelValues(testTopic, "1", testGroup, statusSkipped)))
+	s.Suite.Equal(0.0, testutil.ToFloat64(m.errors.WithLabelValues(testTopic, testGroup, stageHandle)))
+	statuses := s.Consumer.Consumers()
+	s.Suite.Equal(int64(1), statuses[0].Skipped)
+	s.Suite.Equal(int64(1), statuses[0].Handled)
+	s.Suite.Equal(int64(0), statuses[0].Failed)
+}
+
+func (s *ConsumerTestSuite) TestCloseStopsRetries() {
+	reader := &readerStub{messages: []kafka.Message{{Topic: testTopic, Value: []byte("fail")}}}
+	config := NewConsumerConfig(nil, testTopic, testGroup, func(ctx context.Context, key, value []byte) error {
+		return errors.New("postgres is down")
+	})
+	s.Consumer.RegisterConsumer(config)
+
+	stopped := make(chan struct{})
+	go func() {
+		s.Consumer.runReader(reader, 0, config)
+		close(stopped)
+	}()
+	s.Consumer.Close()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		s.Suite.Fail("reader kept retrying after close")
+	}
+	s.Suite.Empty(reader.committed)
+}
+
+func TestConsumerTestSuite(t *testing.T) {
+	suite.Run(t, new(ConsumerTestSuite))
+}
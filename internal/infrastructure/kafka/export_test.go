@@ -0,0 +1,5 @@
+package kafka
+
+// ReplayMessages exposes the replay of a partition to the tests of the
+// kafka_test package, which replay through the consumer handlers
+var ReplayMessages = replayMessages
@@ -0,0 +1,214 @@
+package kafka
+
+import (
+	"fifth_exam/job_service/internal/pkg/metrics"
+	"fifth_exam/job_service/internal/usecase/event"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	metricsSubsystem = "kafka_consumer"
+
+	stageFetch  = "fetch"
+	stageHandle = "handle"
+	stageCommit = "commit"
+
+	statusSuccess = "success"
+	statusError   = "error"
+	statusSkipped = "skipped"
+)
+
+// consumerMetrics keeps the per topic/partition state observed while reading
+// and, as a prometheus.Collector, turns kafka.Reader.Stats snapshots into
+// cumulative counters on every scrape
+type consumerMetrics struct {
+	mu      sync.Mutex
+	readers []*kafka.Reader
+	groups  map[*kafka.Reader]string
+
+	lag             *prometheus.GaugeVec
+	offset          *prometheus.GaugeVec
+	processed       *prometheus.CounterVec
+	handlerDuration *prometheus.HistogramVec
+	errors          *prometheus.CounterVec
+
+	readerMessages   *prometheus.CounterVec
+	readerBytes      *prometheus.CounterVec
+	readerErrors     *prometheus.CounterVec
+	readerRebalances *prometheus.CounterVec
+	readerTimeouts   *prometheus.CounterVec
+	readerLag        *prometheus.GaugeVec
+	readerQueue      *prometheus.GaugeVec
+}
+
+func newConsumerMetrics() *consumerMetrics {
+	partitionLabels := []string{"topic", "partition", "group"}
+	readerLabels := []string{"topic", "group"}
+
+	return &consumerMetrics{
+		groups: make(map[*kafka.Reader]string),
+		lag: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "lag",
+			Help:      "Number of messages between the last fetched offset and the partition high water mark.",
+		}, partitionLabels),
+		offset: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "offset",
+			Help:      "Offset of the last message fetched from the partition.",
+		}, partitionLabels),
+		processed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "messages_processed_total",
+			Help:      "Messages passed to the handler, by result.",
+		}, []string{"topic", "partition", "group", "status"}),
+		handlerDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "handler_duration_seconds",
+			Help:      "Time spent in the message handler.",
+			Buckets:   prometheus.DefBuckets,
+		}, readerLabels),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "errors_total",
+			Help:      "Consumer errors by stage (fetch, handle, commit).",
+		}, []string{"topic", "group", "stage"}),
+		readerMessages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "reader_messages_total",
+			Help:      "Messages read by the kafka reader.",
+		}, readerLabels),
+		readerBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "reader_bytes_total",
+			Help:      "Message bytes read by the kafka reader.",
+		}, readerLabels),
+		readerErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "reader_errors_total",
+			Help:      "Errors reported by the kafka reader.",
+		}, readerLabels),
+		readerRebalances: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "reader_rebalances_total",
+			Help:      "Consumer group rebalances seen by the kafka reader.",
+		}, readerLabels),
+		readerTimeouts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "reader_timeouts_total",
+			Help:      "Fetch timeouts reported by the kafka reader.",
+		}, readerLabels),
+		readerLag: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "reader_lag",
+			Help:      "Lag reported by the kafka reader stats.",
+		}, readerLabels),
+		readerQueue: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "reader_queue_length",
+			Help:      "Messages fetched by the kafka reader but not yet consumed.",
+		}, readerLabels),
+	}
+}
+
+func (m *consumerMetrics) addReader(r *kafka.Reader, group string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.readers = append(m.readers, r)
+	m.groups[r] = group
+}
+
+func (m *consumerMetrics) observeFetch(msg kafka.Message, group string) {
+	partition := strconv.Itoa(msg.Partition)
+
+	m.offset.WithLabelValues(msg.Topic, partition, group).Set(float64(msg.Offset))
+	if msg.HighWaterMark > 0 {
+		lag := msg.HighWaterMark - msg.Offset - 1
+		if lag < 0 {
+			lag = 0
+		}
+		m.lag.WithLabelValues(msg.Topic, partition, group).Set(float64(lag))
+	}
+}
+
+func (m *consumerMetrics) observeHandle(msg kafka.Message, group string, started time.Time, err error) {
+	m.handlerDuration.WithLabelValues(msg.Topic, group).Observe(time.Since(started).Seconds())
+
+	status := statusSuccess
+	switch {
+	case event.Skipped(err):
+		status = statusSkipped
+	case err != nil:
+		status = statusError
+		m.errors.WithLabelValues(msg.Topic, group, stageHandle).Inc()
+	}
+	m.processed.WithLabelValues(msg.Topic, strconv.Itoa(msg.Partition), group, status).Inc()
+}
+
+func (m *consumerMetrics) observeError(topic, group, stage string) {
+	m.errors.WithLabelValues(topic, group, stage).Inc()
+}
+
+func (m *consumerMetrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.lag,
+		m.offset,
+		m.processed,
+		m.handlerDuration,
+		m.errors,
+		m.readerMessages,
+		m.readerBytes,
+		m.readerErrors,
+		m.readerRebalances,
+		m.readerTimeouts,
+		m.readerLag,
+		m.readerQueue,
+	}
+}
+
+func (m *consumerMetrics) Describe(ch chan<- *prometheus.Desc) {
+	for _, c := range m.collectors() {
+		c.Describe(ch)
+	}
+}
+
+// Collect folds the reader stats gathered since the previous scrape into the
+// counters, kafka.Reader.Stats resets its counters on every call
+func (m *consumerMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.mu.Lock()
+	for _, r := range m.readers {
+		stats := r.Stats()
+		group := m.groups[r]
+
+		m.readerMessages.WithLabelValues(stats.Topic, group).Add(float64(stats.Messages))
+		m.readerBytes.WithLabelValues(stats.Topic, group).Add(float64(stats.Bytes))
+		m.readerErrors.WithLabelValues(stats.Topic, group).Add(float64(stats.Errors))
+		m.readerRebalances.WithLabelValues(stats.Topic, group).Add(float64(stats.Rebalances))
+		m.readerTimeouts.WithLabelValues(stats.Topic, group).Add(float64(stats.Timeouts))
+		m.readerLag.WithLabelValues(stats.Topic, group).Set(float64(stats.Lag))
+		m.readerQueue.WithLabelValues(stats.Topic, group).Set(float64(stats.QueueLength))
+	}
+	m.mu.Unlock()
+
+	for _, c := range m.collectors() {
+		c.Collect(ch)
+	}
+}
@@ -0,0 +1,239 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	ResetEarliest  = "earliest"
+	ResetLatest    = "latest"
+	ResetOffset    = "offset"
+	ResetTimestamp = "timestamp"
+)
+
+// OffsetTarget describes where consumer group offsets should be moved to,
+// Offset is used with ResetOffset and Timestamp with ResetTimestamp
+type OffsetTarget struct {
+	Mode      string
+	Offset    int64
+	Timestamp time.Time
+}
+
+func (t OffsetTarget) Validate() error {
+	switch t.Mode {
+	case ResetEarliest, ResetLatest:
+	case ResetOffset:
+		if t.Offset < 0 {
+			return fmt.Errorf("offset must not be negative, got %d", t.Offset)
+		}
+	case ResetTimestamp:
+		if t.Timestamp.IsZero() {
+			return errors.New("timestamp is required")
+		}
+	default:
+		return fmt.Errorf("unknown reset mode %q, expected one of %s, %s, %s, %s",
+			t.Mode, ResetEarliest, ResetLatest, ResetOffset, ResetTimestamp)
+	}
+	return nil
+}
+
+// PartitionOffset is the planned or applied offset change of one partition
+type PartitionOffset struct {
+	Partition int
+	// Committed offset of the group before the reset, -1 when nothing was committed
+	Current int64
+	Target  int64
+	First   int64
+	Last    int64
+}
+
+type OffsetManager struct {
+	brokers []string
+	client  *kafka.Client
+}
+
+func NewOffsetManager(brokers []string) *OffsetManager {
+	return &OffsetManager{
+		brokers: brokers,
+		client: &kafka.Client{
+			Addr:    kafka.TCP(brokers...),
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// Partitions returns the partition ids of the topic in ascending order
+func (o *OffsetManager) Partitions(ctx context.Context, topic string) ([]int, error) {
+	meta, err := o.client.Metadata(ctx, &kafka.MetadataRequest{Topics: []string{topic}})
+	if err != nil {
+		return nil, fmt.Errorf("kafka metadata for topic %s: %w", topic, err)
+	}
+
+	var partitions []int
+	for _, t := range meta.Topics {
+		if t.Name != topic {
+			continue
+		}
+		if t.Error != nil {
+			return nil, fmt.Errorf("kafka metadata for topic %s: %w", topic, t.Error)
+		}
+		for _, p := range t.Partitions {
+			partitions = append(partitions, p.ID)
+		}
+	}
+	if len(partitions) == 0 {
+		return nil, fmt.Errorf("topic %s has no partitions", topic)
+	}
+
+	sort.Ints(partitions)
+	return partitions, nil
+}
+
+// Plan resolves the target offset of every partition of the topic without
+// committing anything
+func (o *OffsetManager) Plan(ctx context.Context, group, topic string, target OffsetTarget) ([]PartitionOffset, error) {
+	if err := target.Validate(); err != nil {
+		return nil, err
+	}
+
+	partitions, err := o.Partitions(ctx, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds, err := o.bounds(ctx, topic, partitions, target)
+	if err != nil {
+		return nil, err
+	}
+
+	committed, err := o.committed(ctx, group, topic, partitions)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := make([]PartitionOffset, 0, len(partitions))
+	for _, partition := range partitions {
+		b := bounds[partition]
+		p := PartitionOffset{
+			Partition: partition,
+			Current:   committed[partition],
+			First:     b.FirstOffset,
+			Last:      b.LastOffset,
+		}
+
+		switch target.Mode {
+		case ResetEarliest:
+			p.Target = b.FirstOffset
+		case ResetLatest:
+			p.Target = b.LastOffset
+		case ResetOffset:
+			p.Target = clamp(target.Offset, b.FirstOffset, b.LastOffset)
+		case ResetTimestamp:
+			// the broker answers -1 when no message is newer than the timestamp
+			p.Target = b.LastOffset
+			for offset := range b.Offsets {
+				if offset >= 0 {
+					p.Target = offset
+				}
+			}
+		}
+		plan = append(plan, p)
+	}
+
+	return plan, nil
+}
+
+// Reset commits the planned offsets for the consumer group, the group must
+// not have active members while offsets are being reset
+func (o *OffsetManager) Reset(ctx context.Context, group, topic string, target OffsetTarget) ([]PartitionOffset, error) {
+	plan, err := o.Plan(ctx, group, topic, target)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := make([]kafka.OffsetCommit, 0, len(plan))
+	for _, p := range plan {
+		commits = append(commits, kafka.OffsetCommit{Partition: p.Partition, Offset: p.Target})
+	}
+
+	res, err := o.client.OffsetCommit(ctx, &kafka.OffsetCommitRequest{
+		GroupID:      group,
+		GenerationID: -1,
+		Topics:       map[string][]kafka.OffsetCommit{topic: commits},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("commit offsets for group %s: %w", group, err)
+	}
+
+	for _, p := range res.Topics[topic] {
+		if p.Error != nil {
+			return nil, fmt.Errorf("commit offset for group %s partition %d: %w", group, p.Partition, p.Error)
+		}
+	}
+
+	return plan, nil
+}
+
+func (o *OffsetManager) bounds(ctx context.Context, topic string, partitions []int, target OffsetTarget) (map[int]kafka.PartitionOffsets, error) {
+	requests := make([]kafka.OffsetRequest, 0, len(partitions)*3)
+	for _, partition := range partitions {
+		requests = append(requests, kafka.FirstOffsetOf(partition), kafka.LastOffsetOf(partition))
+		if target.Mode == ResetTimestamp {
+			requests = append(requests, kafka.TimeOffsetOf(partition, target.Timestamp))
+		}
+	}
+
+	res, err := o.client.ListOffsets(ctx, &kafka.ListOffsetsRequest{
+		Topics: map[string][]kafka.OffsetRequest{topic: requests},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list offsets of topic %s: %w", topic, err)
+	}
+
+	bounds := make(map[int]kafka.PartitionOffsets, len(partitions))
+	for _, p := range res.Topics[topic] {
+		if p.Error != nil {
+			return nil, fmt.Errorf("list offsets of topic %s partition %d: %w", topic, p.Partition, p.Error)
+		}
+		bounds[p.Partition] = p
+	}
+	return bounds, nil
+}
+
+func (o *OffsetManager) committed(ctx context.Context, group, topic string, partitions []int) (map[int]int64, error) {
+	res, err := o.client.OffsetFetch(ctx, &kafka.OffsetFetchRequest{
+		GroupID: group,
+		Topics:  map[string][]int{topic: partitions},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch offsets of group %s: %w", group, err)
+	}
+	if res.Error != nil {
+		return nil, fmt.Errorf("fetch offsets of group %s: %w", group, res.Error)
+	}
+
+	committed := make(map[int]int64, len(partitions))
+	for _, p := range res.Topics[topic] {
+		if p.Error != nil {
+			return nil, fmt.Errorf("fetch offset of group %s partition %d: %w", group, p.Partition, p.Error)
+		}
+		committed[p.Partition] = p.CommittedOffset
+	}
+	return committed, nil
+}
+
+func clamp(offset, first, last int64) int64 {
+	if offset < first {
+		return first
+	}
+	if offset > last {
+		return last
+	}
+	return offset
+}
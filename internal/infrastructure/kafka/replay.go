@@ -0,0 +1,119 @@
+package kafka
+
+import (
+	"context"
+	"fifth_exam/job_service/internal/usecase/event"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// ReplayFailure is a message the handler failed or skipped during a replay
+type ReplayFailure struct {
+	Partition int
+	Offset    int64
+	Err       error
+}
+
+// ReplayStats counts the messages of a replay, Skipped are the messages the
+// handler skipped with event.Skip
+type ReplayStats struct {
+	Read      int
+	Succeeded int
+	Failures  []ReplayFailure
+	Skipped   []ReplayFailure
+}
+
+// Replay passes every message of the topic produced between from and to to
+// the handler, partition by partition. Messages are read outside of any
+// consumer group so committed offsets are left untouched. A zero to replays
+// up to the end offsets observed when the replay started.
+func (o *OffsetManager) Replay(ctx context.Context, topic string, from, to time.Time, handler HandlerFunc) (ReplayStats, error) {
+	var stats ReplayStats
+
+	if !to.IsZero() && to.Before(from) {
+		return stats, fmt.Errorf("replay window ends (%s) before it starts (%s)", to, from)
+	}
+
+	partitions, err := o.Partitions(ctx, topic)
+	if err != nil {
+		return stats, err
+	}
+
+	bounds, err := o.bounds(ctx, topic, partitions, OffsetTarget{Mode: ResetTimestamp, Timestamp: from})
+	if err != nil {
+		return stats, err
+	}
+
+	for _, partition := range partitions {
+		b := bounds[partition]
+		start := b.LastOffset
+		for offset := range b.Offsets {
+			if offset >= 0 {
+				start = offset
+			}
+		}
+
+		if err := o.replayPartition(ctx, topic, partition, start, b.LastOffset, to, handler, &stats); err != nil {
+			return stats, err
+		}
+	}
+
+	return stats, nil
+}
+
+func (o *OffsetManager) replayPartition(ctx context.Context, topic string, partition int, start, end int64, to time.Time, handler HandlerFunc, stats *ReplayStats) error {
+	if start >= end {
+		return nil
+	}
+
+	r := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:   o.brokers,
+		Topic:     topic,
+		Partition: partition,
+		MinBytes:  MinBytes,
+		MaxBytes:  MaxBytes,
+	})
+	defer r.Close()
+
+	if err := r.SetOffset(start); err != nil {
+		return fmt.Errorf("replay set offset %d on partition %d: %w", start, partition, err)
+	}
+
+	return replayMessages(ctx, r, partition, start, end, to, handler, stats)
+}
+
+// partitionReader is the part of kafka.Reader a replay reads a partition with
+type partitionReader interface {
+	ReadMessage(ctx context.Context) (kafka.Message, error)
+}
+
+// replayMessages passes the messages of r from start to end, or up to the
+// first one produced after to, to the handler
+func replayMessages(ctx context.Context, r partitionReader, partition int, start, end int64, to time.Time, handler HandlerFunc, stats *ReplayStats) error {
+	for offset := start; offset < end; {
+		m, err := r.ReadMessage(ctx)
+		if err != nil {
+			return fmt.Errorf("replay read partition %d at offset %d: %w", partition, offset, err)
+		}
+		offset = m.Offset + 1
+
+		if !to.IsZero() && m.Time.After(to) {
+			return nil
+		}
+
+		stats.Read++
+		err = handler(ctx, m.Key, m.Value)
+		switch {
+		case event.Skipped(err):
+			stats.Skipped = append(stats.Skipped, ReplayFailure{Partition: m.Partition, Offset: m.Offset, Err: err})
+		case err != nil:
+			stats.Failures = append(stats.Failures, ReplayFailure{Partition: m.Partition, Offset: m.Offset, Err: err})
+		default:
+			stats.Succeeded++
+		}
+	}
+
+	return nil
+}
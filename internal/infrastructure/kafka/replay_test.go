@@ -0,0 +1,121 @@
+package kafka_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	pb "fifth_exam/job_service/genproto/job_service"
+	"fifth_exam/job_service/internal/delivery/kafka/handlers"
+	"fifth_exam/job_service/internal/entity"
+	"fifth_exam/job_service/internal/infrastructure/kafka"
+	"fifth_exam/job_service/internal/pkg/config"
+	"fifth_exam/job_service/internal/usecase"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+// jobUsecaseStub fails the creation of the jobs in errs, its other methods
+// are not used by the job topic handler
+type jobUsecaseStub struct {
+	usecase.Job
+	errs    map[string]error
+	created []string
+}
+
+func (j *jobUsecaseStub) Create(ctx context.Context, req *entity.Job) (*entity.Job, error) {
+	if err := j.errs[req.Id]; err != nil {
+		return nil, err
+	}
+	j.created = append(j.created, req.Id)
+	return req, nil
+}
+
+type partitionReaderStub struct {
+	messages []kafkago.Message
+}
+
+func (r *partitionReaderStub) ReadMessage(ctx context.Context) (kafkago.Message, error) {
+	if len(r.messages) == 0 {
+		return kafkago.Message{}, io.EOF
+	}
+	m := r.messages[0]
+	r.messages = r.messages[1:]
+	return m, nil
+}
+
+type ReplayTestSuite struct {
+	suite.Suite
+	Usecase *jobUsecaseStub
+	Handler kafka.HandlerFunc
+}
+
+func (s *ReplayTestSuite) SetupTest() {
+	s.Usecase = &jobUsecaseStub{errs: map[string]error{}}
+	s.Handler = handlers.NewUserConsumerHandler(config.New(), nil, zap.NewNop(), s.Usecase).HandleJobCreate
+}
+
+func (s *ReplayTestSuite) message(offset int64, job *pb.Job) kafkago.Message {
+	value, err := json.Marshal(job)
+	s.Suite.NoError(err)
+	return kafkago.Message{Partition: 2, Offset: offset, Key: []byte(job.Id), Value: value, Time: time.Now()}
+}
+
+func (s *ReplayTestSuite) job() *pb.Job {
+	return &pb.Job{Id: uuid.New().String(), Title: "title", OwnerId: uuid.New().String(), FromDate: "2024-05-01", ToDate: "2024-05-31"}
+}
+
+func (s *ReplayTestSuite) TestFailedCreateIsAFailure() {
+	var (
+		created   = s.job()
+		failed    = s.job()
+		duplicate = s.job()
+	)
+	errPostgres := errors.New("connection refused")
+	s.Usecase.errs[failed.Id] = errPostgres
+	s.Usecase.errs[duplicate.Id] = entity.ErrorConflict
+
+	reader := &partitionReaderStub{messages: []kafkago.Message{
+		s.message(10, created),
+		s.message(11, failed),
+		s.message(12, duplicate),
+		s.message(13, &pb.Job{Id: "not-a-uuid"}),
+	}}
+
+	var stats kafka.ReplayStats
+	s.Suite.NoError(kafka.ReplayMessages(context.Background(), reader, 2, 10, 14, time.Time{}, s.Handler, &stats))
+
+	s.Suite.Equal(4, stats.Read)
+	s.Suite.Equal(1, stats.Succeeded)
+	s.Suite.Len(stats.Failures, 1)
+	s.Suite.Equal(int64(11), stats.Failures[0].Offset)
+	s.Suite.Equal(2, stats.Failures[0].Partition)
+	s.Suite.ErrorIs(stats.Failures[0].Err, errPostgres)
+	// the duplicate was created before and the invalid job can never be, the
+	// consumer commits both without retrying
+	s.Suite.Len(stats.Skipped, 2)
+	s.Suite.Equal(int64(12), stats.Skipped[0].Offset)
+	s.Suite.ErrorIs(stats.Skipped[0].Err, entity.ErrorConflict)
+	s.Suite.Equal(int64(13), stats.Skipped[1].Offset)
+	var errValidation *entity.ErrValidation
+	s.Suite.ErrorAs(stats.Skipped[1].Err, &errValidation)
+	s.Suite.Equal([]string{created.Id}, s.Usecase.created)
+}
+
+func (s *ReplayTestSuite) TestReadErrorStopsTheReplay() {
+	reader := &partitionReaderStub{messages: []kafkago.Message{s.message(0, s.job())}}
+
+	var stats kafka.ReplayStats
+	err := kafka.ReplayMessages(context.Background(), reader, 0, 0, 2, time.Time{}, s.Handler, &stats)
+	s.Suite.ErrorIs(err, io.EOF)
+	s.Suite.Equal(1, stats.Succeeded)
+}
+
+func TestReplayTestSuite(t *testing.T) {
+	suite.Run(t, new(ReplayTestSuite))
+}
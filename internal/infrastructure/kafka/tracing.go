@@ -0,0 +1,62 @@
+package kafka
+
+import (
+	"context"
+	logpkg "fifth_exam/job_service/internal/pkg/logger"
+	"fifth_exam/job_service/internal/pkg/otlp"
+
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// handle runs handler within a consumer span, child of the trace propagated
+// in the headers of m when the producer sent one, and with a logger naming
+// the message in its context
+func (c *consumer) handle(m kafka.Message, group string, handler HandlerFunc) error {
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), headerCarrier(m.Headers))
+	ctx = logpkg.WithContext(ctx, c.logger.With(
+		zap.String("topic", m.Topic),
+		zap.Int("partition", m.Partition),
+		zap.Int64("offset", m.Offset),
+	))
+
+	return otlp.TraceError(ctx, otlp.TracerConsumer, m.Topic+" deliver", func(ctx context.Context) error {
+		return handler(ctx, m.Key, m.Value)
+	},
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingOperationDeliver,
+			semconv.MessagingDestinationName(m.Topic),
+			semconv.MessagingKafkaConsumerGroup(group),
+			semconv.MessagingKafkaDestinationPartition(m.Partition),
+			semconv.MessagingKafkaMessageOffset(int(m.Offset)),
+		),
+	)
+}
+
+// headerCarrier reads the trace context of a message from its headers
+type headerCarrier []kafka.Header
+
+func (h headerCarrier) Get(key string) string {
+	for _, header := range h {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+// Set is not used on consumed messages
+func (h headerCarrier) Set(key, value string) {}
+
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for _, header := range h {
+		keys = append(keys, header.Key)
+	}
+	return keys
+}
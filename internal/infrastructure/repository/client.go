@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"fifth_exam/job_service/internal/entity"
+)
+
+type Client interface {
+	Create(ctx context.Context, req *entity.Client) (*entity.Client, error)
+	Get(ctx context.Context, field, value string) (*entity.Client, error)
+	// GetForUpdate and GetForShare lock the client until the end of the
+	// transaction of ctx, exclusively or against updates and deletes
+	GetForUpdate(ctx context.Context, field, value string) (*entity.Client, error)
+	GetForShare(ctx context.Context, field, value string) (*entity.Client, error)
+	List(ctx context.Context, req *entity.GetListFilter) ([]*entity.Client, error)
+	Update(ctx context.Context, req *entity.Client) error
+	SetActive(ctx context.Context, field, value string, active bool) error
+	Delete(ctx context.Context, field, value string) error
+}
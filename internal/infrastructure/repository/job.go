@@ -3,12 +3,18 @@ package repository
 import (
 	"context"
 	"fifth_exam/job_service/internal/entity"
+	"time"
 )
 
 type Job interface {
 	Create(ctx context.Context, req *entity.Job) (*entity.Job, error)
 	Get(ctx context.Context, field, value string) (*entity.Job, error)
+	// GetForUpdate locks the job until the end of the transaction of ctx
+	GetForUpdate(ctx context.Context, field, value string) (*entity.Job, error)
 	List(ctx context.Context, req *entity.GetListFilter) ([]*entity.Job, error)
 	Update(ctx context.Context, req *entity.Job) error
 	Delete(ctx context.Context, field, value string) error
+	DeleteOpenByOwner(ctx context.Context, ownerId string, now time.Time) (int64, error)
+	CancelInProgressByOwner(ctx context.Context, ownerId string, now time.Time) (int64, error)
+	CountActiveByOwner(ctx context.Context, ownerId string, now time.Time) (int64, error)
 }
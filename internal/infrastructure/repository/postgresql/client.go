@@ -0,0 +1,312 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fifth_exam/job_service/internal/entity"
+	"fifth_exam/job_service/internal/pkg/otlp"
+	"fifth_exam/job_service/internal/pkg/postgres"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+)
+
+const clientsTableName = "clients"
+
+// clientOrderColumns are the columns a client list can be ordered by
+var clientOrderColumns = map[string]bool{
+	"username":   true,
+	"email":      true,
+	"created_at": true,
+	"updated_at": true,
+}
+
+type ClientRepo struct {
+	tableName string
+	db        *postgres.PostgresDB
+}
+
+func NewClientRepo(db *postgres.PostgresDB) *ClientRepo {
+	return &ClientRepo{
+		tableName: clientsTableName,
+		db:        db,
+	}
+}
+
+func (c *ClientRepo) Create(ctx context.Context, req *entity.Client) (*entity.Client, error) {
+	data := map[string]interface{}{
+		"id":         req.Id,
+		"username":   req.Username,
+		"email":      req.Email,
+		"phone":      req.Phone,
+		"address":    req.Address,
+		"is_active":  req.IsActive,
+		"created_at": req.CreatedAt,
+	}
+
+	query, args, err := c.db.Sq.Builder.Insert(c.tableName).SetMap(data).ToSql()
+	if err != nil {
+		return nil, c.db.ErrSQLBuild(err, fmt.Sprintf("%s %s", c.tableName, "create"))
+	}
+	otlp.Statement(ctx, query)
+
+	_, err = c.db.Querier(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return nil, c.db.Error(err)
+	}
+
+	return req, nil
+}
+
+func (c *ClientRepo) Get(ctx context.Context, field, value string) (*entity.Client, error) {
+	return c.get(ctx, field, value, "")
+}
+
+// GetForUpdate is Get locking the client row until the end of the
+// transaction of ctx, concurrent locking reads wait for it
+func (c *ClientRepo) GetForUpdate(ctx context.Context, field, value string) (*entity.Client, error) {
+	return c.get(ctx, field, value, "FOR UPDATE")
+}
+
+// GetForShare is Get keeping the client row from being updated or deleted
+// until the end of the transaction of ctx
+func (c *ClientRepo) GetForShare(ctx context.Context, field, value string) (*entity.Client, error) {
+	return c.get(ctx, field, value, "FOR SHARE")
+}
+
+// get selects the client that is not deleted, lock is the locking clause of
+// the select if any
+func (c *ClientRepo) get(ctx context.Context, field, value, lock string) (*entity.Client, error) {
+	queryBuilder := c.clientsSelectQueryPrefix().Where(
+		squirrel.And{
+			squirrel.Eq{field: value},
+			squirrel.Eq{"deleted_at": nil},
+		},
+	)
+	if lock != "" {
+		queryBuilder = queryBuilder.Suffix(lock)
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, c.db.ErrSQLBuild(err, fmt.Sprintf("%s %s", c.tableName, "get"))
+	}
+	otlp.Statement(ctx, query)
+
+	client, err := scanClient(c.db.Querier(ctx).QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, c.db.Error(err)
+	}
+
+	return client, nil
+}
+
+// List returns a page of clients, Search matches the username, email or
+// phone and OrderBy takes a column optionally followed by asc or desc
+func (c *ClientRepo) List(ctx context.Context, req *entity.GetListFilter) ([]*entity.Client, error) {
+	queryBuilder := c.clientsSelectQueryPrefix()
+
+	if req.Limit != 0 {
+		offset := (req.Page - 1) * req.Limit
+		if offset < 0 {
+			offset = 0
+		}
+		queryBuilder = queryBuilder.Limit(uint64(req.Limit)).Offset(uint64(offset))
+	}
+
+	orderBy, err := clientOrderBy(req.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+	queryBuilder = queryBuilder.OrderBy(orderBy)
+
+	if search := strings.TrimSpace(req.Search); search != "" {
+		pattern := "%" + search + "%"
+		queryBuilder = queryBuilder.Where(squirrel.Or{
+			squirrel.ILike{"username": pattern},
+			squirrel.ILike{"email": pattern},
+			squirrel.ILike{"phone": pattern},
+		})
+	}
+
+	if !req.IncludeDeleted {
+		queryBuilder = queryBuilder.Where(squirrel.Eq{"deleted_at": nil})
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, c.db.ErrSQLBuild(err, fmt.Sprintf("%s %s", c.tableName, "list"))
+	}
+	otlp.Statement(ctx, query)
+
+	rows, err := c.db.Querier(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, c.db.Error(err)
+	}
+	defer rows.Close()
+
+	var clients []*entity.Client
+	for rows.Next() {
+		client, err := scanClient(rows)
+		if err != nil {
+			return nil, c.db.Error(err)
+		}
+		clients = append(clients, client)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, c.db.Error(err)
+	}
+
+	return clients, nil
+}
+
+func (c *ClientRepo) Update(ctx context.Context, req *entity.Client) error {
+	data := map[string]interface{}{
+		"username":   req.Username,
+		"email":      req.Email,
+		"phone":      req.Phone,
+		"address":    req.Address,
+		"updated_at": req.UpdatedAt,
+	}
+
+	sqlStr, args, err := c.db.Sq.Builder.
+		Update(c.tableName).
+		SetMap(data).
+		Where(squirrel.Eq{"id": req.Id, "deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return c.db.ErrSQLBuild(err, c.tableName+" update")
+	}
+	otlp.Statement(ctx, sqlStr)
+
+	commandTag, err := c.db.Querier(ctx).Exec(ctx, sqlStr, args...)
+	if err != nil {
+		return c.db.Error(err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return entity.NewErrNotFound("client")
+	}
+
+	return nil
+}
+
+// SetActive activates or deactivates the client that is not deleted
+func (c *ClientRepo) SetActive(ctx context.Context, field, value string, active bool) error {
+	sqlStr, args, err := c.db.Sq.Builder.
+		Update(c.tableName).
+		Set("is_active", active).
+		Set("updated_at", time.Now()).
+		Where(squirrel.Eq{field: value, "deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return c.db.ErrSQLBuild(err, c.tableName+" set active")
+	}
+	otlp.Statement(ctx, sqlStr)
+
+	commandTag, err := c.db.Querier(ctx).Exec(ctx, sqlStr, args...)
+	if err != nil {
+		return c.db.Error(err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return entity.NewErrNotFound("client")
+	}
+
+	return nil
+}
+
+// Delete marks the client as deleted, the row is kept for the jobs it owns
+func (c *ClientRepo) Delete(ctx context.Context, field, value string) error {
+	sqlStr, args, err := c.db.Sq.Builder.
+		Update(c.tableName).
+		Set("is_deleted", true).
+		Set("deleted_at", time.Now()).
+		Where(squirrel.Eq{field: value, "deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return c.db.ErrSQLBuild(err, c.tableName+" soft delete")
+	}
+	otlp.Statement(ctx, sqlStr)
+
+	commandTag, err := c.db.Querier(ctx).Exec(ctx, sqlStr, args...)
+	if err != nil {
+		return c.db.Error(err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return entity.NewErrNotFound("client")
+	}
+
+	return nil
+}
+
+func (c *ClientRepo) clientsSelectQueryPrefix() squirrel.SelectBuilder {
+	return c.db.Sq.Builder.Select(
+		"id",
+		"username",
+		"email",
+		"phone",
+		"address",
+		"is_active",
+		"COALESCE(is_deleted, false)",
+		"created_at",
+		"updated_at",
+		"deleted_at",
+	).From(c.tableName)
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanClient(row scanner) (*entity.Client, error) {
+	var (
+		client    entity.Client
+		createdAt sql.NullTime
+		updatedAt sql.NullTime
+		deletedAt sql.NullTime
+	)
+	if err := row.Scan(
+		&client.Id,
+		&client.Username,
+		&client.Email,
+		&client.Phone,
+		&client.Address,
+		&client.IsActive,
+		&client.IsDeleted,
+		&createdAt,
+		&updatedAt,
+		&deletedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	client.CreatedAt = createdAt.Time
+	client.UpdatedAt = updatedAt.Time
+	client.DeletedAt = deletedAt.Time
+
+	return &client, nil
+}
+
+func clientOrderBy(orderBy string) (string, error) {
+	if strings.TrimSpace(orderBy) == "" {
+		return "created_at DESC", nil
+	}
+
+	column, direction, _ := strings.Cut(strings.TrimSpace(orderBy), " ")
+	direction = strings.ToUpper(strings.TrimSpace(direction))
+	if !clientOrderColumns[column] || (direction != "" && direction != "ASC" && direction != "DESC") {
+		errValidation := entity.NewErrValidation()
+		errValidation.Errors["order_by"] = "must be one of username, email, created_at, updated_at optionally followed by asc or desc"
+		errValidation.Err = fmt.Errorf("invalid order_by %q", orderBy)
+		return "", errValidation
+	}
+
+	if direction == "" {
+		return column, nil
+	}
+	return column + " " + direction, nil
+}
@@ -10,14 +10,9 @@ import (
 	"time"
 
 	"github.com/Masterminds/squirrel"
-	"go.opentelemetry.io/otel/attribute"
 )
 
-const (
-	jobsTableName     = "jobs"
-	jobServiceName    = "jobService"
-	jobSpanRepoPrefix = "jobServiceRepo"
-)
+const jobsTableName = "jobs"
 
 type JobRepo struct {
 	tableName string
@@ -32,12 +27,6 @@ func NewJobRepo(db *postgres.PostgresDB) *JobRepo {
 }
 
 func (j *JobRepo) Create(ctx context.Context, req *entity.Job) (*entity.Job, error) {
-	ctx, span := otlp.Start(ctx, jobServiceName, jobSpanRepoPrefix+"Create")
-	defer span.End()
-
-	span.SetAttributes(attribute.KeyValue{Key: "sql", Value: attribute.StringValue("Creating job")})
-	span.AddEvent("starting creating job")
-
 	data := map[string]interface{}{
 		"id":          req.Id,
 		"title":       req.Title,
@@ -53,8 +42,9 @@ func (j *JobRepo) Create(ctx context.Context, req *entity.Job) (*entity.Job, err
 	if err != nil {
 		return nil, j.db.ErrSQLBuild(err, fmt.Sprintf("%s %s", j.tableName, "create"))
 	}
+	otlp.Statement(ctx, query)
 
-	_, err = j.db.Exec(ctx, query, args...)
+	_, err = j.db.Querier(ctx).Exec(ctx, query, args...)
 	if err != nil {
 		return nil, j.db.Error(err)
 	}
@@ -63,11 +53,16 @@ func (j *JobRepo) Create(ctx context.Context, req *entity.Job) (*entity.Job, err
 }
 
 func (j *JobRepo) Get(ctx context.Context, field, value string) (*entity.Job, error) {
-	ctx, span := otlp.Start(ctx, jobServiceName, jobSpanRepoPrefix+"Get")
-	defer span.End()
+	return j.get(ctx, field, value, "")
+}
 
-	span.SetAttributes(attribute.KeyValue{Key: "sql", Value: attribute.StringValue("Getting job")})
+// GetForUpdate is Get locking the job row until the end of the transaction
+// of ctx, concurrent locking reads wait for it
+func (j *JobRepo) GetForUpdate(ctx context.Context, field, value string) (*entity.Job, error) {
+	return j.get(ctx, field, value, "FOR UPDATE")
+}
 
+func (j *JobRepo) get(ctx context.Context, field, value, lock string) (*entity.Job, error) {
 	var job entity.Job
 
 	queryBuilder := j.jobsSelectQueryPrefix().Where(
@@ -76,18 +71,21 @@ func (j *JobRepo) Get(ctx context.Context, field, value string) (*entity.Job, er
 			squirrel.Eq{"deleted_at": nil},
 		},
 	)
+	if lock != "" {
+		queryBuilder = queryBuilder.Suffix(lock)
+	}
 	query, args, err := queryBuilder.ToSql()
 	if err != nil {
-		fmt.Println(err)
-		fmt.Println("Object not found")
 		return nil, j.db.ErrSQLBuild(err, fmt.Sprintf("%s %s", j.tableName, "get"))
 	}
+	otlp.Statement(ctx, query)
 
 	var (
-		deletedAt sql.NullTime
-		updatedAt sql.NullTime
+		deletedAt   sql.NullTime
+		updatedAt   sql.NullTime
+		cancelledAt sql.NullTime
 	)
-	if err = j.db.QueryRow(ctx, query, args...).Scan(
+	if err = j.db.Querier(ctx).QueryRow(ctx, query, args...).Scan(
 		&job.Id,
 		&job.Title,
 		&job.Description,
@@ -98,6 +96,7 @@ func (j *JobRepo) Get(ctx context.Context, field, value string) (*entity.Job, er
 		&deletedAt,
 		&job.FromDate,
 		&job.ToDate,
+		&cancelledAt,
 	); err != nil {
 		return nil, j.db.Error(err)
 	}
@@ -105,16 +104,14 @@ func (j *JobRepo) Get(ctx context.Context, field, value string) (*entity.Job, er
 	if updatedAt.Valid {
 		job.UpdatedAt = updatedAt.Time
 	}
+	if cancelledAt.Valid {
+		job.CancelledAt = cancelledAt.Time
+	}
 
 	return &job, nil
 }
 
 func (j *JobRepo) List(ctx context.Context, req *entity.GetListFilter) ([]*entity.Job, error) {
-	ctx, span := otlp.Start(ctx, jobServiceName, jobSpanRepoPrefix+"List")
-	defer span.End()
-
-	span.SetAttributes(attribute.KeyValue{Key: "sql", Value: attribute.StringValue("Getting job list")})
-
 	var jobs []*entity.Job
 
 	queryBuilder := j.jobsSelectQueryPrefix()
@@ -133,12 +130,17 @@ func (j *JobRepo) List(ctx context.Context, req *entity.GetListFilter) ([]*entit
 		queryBuilder = queryBuilder.Where(squirrel.Eq{"deleted_at": nil})
 	}
 
+	if req.OwnerId != "" {
+		queryBuilder = queryBuilder.Where(squirrel.Eq{"owner_id": req.OwnerId})
+	}
+
 	query, args, err := queryBuilder.ToSql()
 	if err != nil {
 		return nil, j.db.ErrSQLBuild(err, fmt.Sprintf("%s %s", j.tableName, "list"))
 	}
+	otlp.Statement(ctx, query)
 
-	rows, err := j.db.Query(ctx, query, args...)
+	rows, err := j.db.Querier(ctx).Query(ctx, query, args...)
 	if err != nil {
 		return nil, j.db.Error(err)
 	}
@@ -148,6 +150,7 @@ func (j *JobRepo) List(ctx context.Context, req *entity.GetListFilter) ([]*entit
 		var job entity.Job
 		var deletedAt sql.NullTime
 		var updatedAt sql.NullTime
+		var cancelledAt sql.NullTime
 		if err = rows.Scan(
 			&job.Id,
 			&job.Title,
@@ -159,6 +162,7 @@ func (j *JobRepo) List(ctx context.Context, req *entity.GetListFilter) ([]*entit
 			&deletedAt,
 			&job.FromDate,
 			&job.ToDate,
+			&cancelledAt,
 		); err != nil {
 			return nil, j.db.Error(err)
 		}
@@ -169,6 +173,9 @@ func (j *JobRepo) List(ctx context.Context, req *entity.GetListFilter) ([]*entit
 		if deletedAt.Valid {
 			job.DeletedAt = deletedAt.Time
 		}
+		if cancelledAt.Valid {
+			job.CancelledAt = cancelledAt.Time
+		}
 		jobs = append(jobs, &job)
 	}
 
@@ -176,11 +183,6 @@ func (j *JobRepo) List(ctx context.Context, req *entity.GetListFilter) ([]*entit
 }
 
 func (j *JobRepo) Update(ctx context.Context, req *entity.Job) error {
-	ctx, span := otlp.Start(ctx, jobServiceName, jobSpanRepoPrefix+"Update")
-	defer span.End()
-
-	span.SetAttributes(attribute.KeyValue{Key: "sql", Value: attribute.StringValue("Updating job")})
-
 	data := map[string]interface{}{
 		"title":       req.Title,
 		"description": req.Description,
@@ -199,8 +201,9 @@ func (j *JobRepo) Update(ctx context.Context, req *entity.Job) error {
 	if err != nil {
 		return j.db.ErrSQLBuild(err, j.tableName+" update")
 	}
+	otlp.Statement(ctx, sqlStr)
 
-	commandTag, err := j.db.Exec(ctx, sqlStr, args...)
+	commandTag, err := j.db.Querier(ctx).Exec(ctx, sqlStr, args...)
 	if err != nil {
 		return j.db.Error(err)
 	}
@@ -213,11 +216,6 @@ func (j *JobRepo) Update(ctx context.Context, req *entity.Job) error {
 }
 
 func (j *JobRepo) Delete(ctx context.Context, field, value string) error {
-	ctx, span := otlp.Start(ctx, jobServiceName, jobSpanRepoPrefix+"SoftDelete")
-	defer span.End()
-
-	span.SetAttributes(attribute.KeyValue{Key: "sql", Value: attribute.StringValue("Soft deleting job")})
-
 	sqlStr, args, err := j.db.Sq.Builder.
 		Update(j.tableName).
 		Set("deleted_at", time.Now()).
@@ -226,8 +224,9 @@ func (j *JobRepo) Delete(ctx context.Context, field, value string) error {
 	if err != nil {
 		return j.db.ErrSQLBuild(err, j.tableName+" soft delete")
 	}
+	otlp.Statement(ctx, sqlStr)
 
-	_, err = j.db.Exec(ctx, sqlStr, args...)
+	_, err = j.db.Querier(ctx).Exec(ctx, sqlStr, args...)
 	if err != nil {
 		return j.db.Error(err)
 	}
@@ -235,6 +234,80 @@ func (j *JobRepo) Delete(ctx context.Context, field, value string) error {
 	return nil
 }
 
+// DeleteOpenByOwner soft deletes the jobs of the owner that have not started
+// at now and returns how many were deleted
+func (j *JobRepo) DeleteOpenByOwner(ctx context.Context, ownerId string, now time.Time) (int64, error) {
+	sqlStr, args, err := j.db.Sq.Builder.
+		Update(j.tableName).
+		Set("deleted_at", now).
+		Where(j.activeByOwner(ownerId, now)).
+		Where(squirrel.Gt{"from_date": now.Format(entity.JobDateLayout)}).
+		ToSql()
+	if err != nil {
+		return 0, j.db.ErrSQLBuild(err, j.tableName+" delete open by owner")
+	}
+	otlp.Statement(ctx, sqlStr)
+
+	commandTag, err := j.db.Querier(ctx).Exec(ctx, sqlStr, args...)
+	if err != nil {
+		return 0, j.db.Error(err)
+	}
+
+	return commandTag.RowsAffected(), nil
+}
+
+// CancelInProgressByOwner cancels the jobs of the owner that are running at
+// now and returns how many were cancelled
+func (j *JobRepo) CancelInProgressByOwner(ctx context.Context, ownerId string, now time.Time) (int64, error) {
+	sqlStr, args, err := j.db.Sq.Builder.
+		Update(j.tableName).
+		Set("cancelled_at", now).
+		Set("updated_at", now).
+		Where(j.activeByOwner(ownerId, now)).
+		Where(squirrel.LtOrEq{"from_date": now.Format(entity.JobDateLayout)}).
+		ToSql()
+	if err != nil {
+		return 0, j.db.ErrSQLBuild(err, j.tableName+" cancel in progress by owner")
+	}
+	otlp.Statement(ctx, sqlStr)
+
+	commandTag, err := j.db.Querier(ctx).Exec(ctx, sqlStr, args...)
+	if err != nil {
+		return 0, j.db.Error(err)
+	}
+
+	return commandTag.RowsAffected(), nil
+}
+
+// CountActiveByOwner counts the open and in-progress jobs of the owner at now
+func (j *JobRepo) CountActiveByOwner(ctx context.Context, ownerId string, now time.Time) (int64, error) {
+	query, args, err := j.db.Sq.Builder.
+		Select("COUNT(*)").
+		From(j.tableName).
+		Where(j.activeByOwner(ownerId, now)).
+		ToSql()
+	if err != nil {
+		return 0, j.db.ErrSQLBuild(err, j.tableName+" count active by owner")
+	}
+	otlp.Statement(ctx, query)
+
+	var count int64
+	if err := j.db.Querier(ctx).QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, j.db.Error(err)
+	}
+
+	return count, nil
+}
+
+// activeByOwner matches the jobs of the owner that are neither deleted,
+// cancelled nor finished at now
+func (j *JobRepo) activeByOwner(ownerId string, now time.Time) squirrel.Sqlizer {
+	return squirrel.And{
+		squirrel.Eq{"owner_id": ownerId, "deleted_at": nil, "cancelled_at": nil},
+		squirrel.GtOrEq{"to_date": now.Format(entity.JobDateLayout)},
+	}
+}
+
 func (j *JobRepo) jobsSelectQueryPrefix() squirrel.SelectBuilder {
 	return j.db.Sq.Builder.Select(
 		"id",
@@ -247,5 +320,6 @@ func (j *JobRepo) jobsSelectQueryPrefix() squirrel.SelectBuilder {
 		"deleted_at",
 		"from_date",
 		"to_date",
+		"cancelled_at",
 	).From(j.tableName)
 }
@@ -12,6 +12,8 @@ import (
 	"github.com/stretchr/testify/suite"
 )
 
+const testOwnerId = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+
 type JobTestSite struct {
 	suite.Suite
 	Repository  *JobRepo
@@ -19,9 +21,16 @@ type JobTestSite struct {
 }
 
 func (s *JobTestSite) SetupSuite() {
-	pgPool, _ := postgres.New(config.New())
+	pgPool, err := postgres.New(config.New())
+	s.Suite.Require().NoError(err)
 	s.Repository = NewJobRepo(pgPool)
 	s.CleanUpFunc = pgPool.Close
+
+	// migrations leave no data, the owner of the test job is created here
+	_, err = pgPool.Exec(context.Background(), `INSERT INTO clients (id, username, email, phone, address)
+		VALUES ($1, 'John Doe', 'john@example.com', '+1234567890', '123 Main Street, Anytown, USA')
+		ON CONFLICT DO NOTHING`, testOwnerId)
+	s.Suite.NoError(err)
 }
 
 func (j *JobTestSite) TestJobCRUD() {
@@ -32,7 +41,7 @@ func (j *JobTestSite) TestJobCRUD() {
 		Id:          uuid.New().String(),
 		Title:       "New title",
 		Description: "New Description",
-		OwnerId:     "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+		OwnerId:     testOwnerId,
 		Price:       12412.12,
 		FromDate:    "2023-12-12",
 		ToDate:      "2023-12-27",
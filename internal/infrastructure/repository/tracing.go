@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"fifth_exam/job_service/internal/entity"
+	"fifth_exam/job_service/internal/pkg/otlp"
+	"time"
+)
+
+const (
+	jobsTable    = "jobs"
+	clientsTable = "clients"
+)
+
+type jobTracing struct {
+	next Job
+}
+
+// NewJobTracing wraps a job repository and runs every call in a client span
+// named after the method, e.g. "JobRepo.Get", that records the error of
+// failed queries. Repositories add the statement with otlp.Statement.
+func NewJobTracing(next Job) Job {
+	return &jobTracing{next: next}
+}
+
+func (t *jobTracing) Create(ctx context.Context, req *entity.Job) (*entity.Job, error) {
+	return otlp.Trace(ctx, otlp.TracerRepository, "JobRepo.Create", func(ctx context.Context) (*entity.Job, error) {
+		return t.next.Create(ctx, req)
+	}, otlp.Query("INSERT", jobsTable)...)
+}
+
+func (t *jobTracing) Get(ctx context.Context, field, value string) (*entity.Job, error) {
+	return otlp.Trace(ctx, otlp.TracerRepository, "JobRepo.Get", func(ctx context.Context) (*entity.Job, error) {
+		return t.next.Get(ctx, field, value)
+	}, otlp.Query("SELECT", jobsTable)...)
+}
+
+func (t *jobTracing) GetForUpdate(ctx context.Context, field, value string) (*entity.Job, error) {
+	return otlp.Trace(ctx, otlp.TracerRepository, "JobRepo.GetForUpdate", func(ctx context.Context) (*entity.Job, error) {
+		return t.next.GetForUpdate(ctx, field, value)
+	}, otlp.Query("SELECT", jobsTable)...)
+}
+
+func (t *jobTracing) List(ctx context.Context, req *entity.GetListFilter) ([]*entity.Job, error) {
+	return otlp.Trace(ctx, otlp.TracerRepository, "JobRepo.List", func(ctx context.Context) ([]*entity.Job, error) {
+		return t.next.List(ctx, req)
+	}, otlp.Query("SELECT", jobsTable)...)
+}
+
+func (t *jobTracing) Update(ctx context.Context, req *entity.Job) error {
+	return otlp.TraceError(ctx, otlp.TracerRepository, "JobRepo.Update", func(ctx context.Context) error {
+		return t.next.Update(ctx, req)
+	}, otlp.Query("UPDATE", jobsTable)...)
+}
+
+func (t *jobTracing) Delete(ctx context.Context, field, value string) error {
+	return otlp.TraceError(ctx, otlp.TracerRepository, "JobRepo.Delete", func(ctx context.Context) error {
+		return t.next.Delete(ctx, field, value)
+	}, otlp.Query("UPDATE", jobsTable)...)
+}
+
+func (t *jobTracing) DeleteOpenByOwner(ctx context.Context, ownerId string, now time.Time) (int64, error) {
+	return otlp.Trace(ctx, otlp.TracerRepository, "JobRepo.DeleteOpenByOwner", func(ctx context.Context) (int64, error) {
+		return t.next.DeleteOpenByOwner(ctx, ownerId, now)
+	}, otlp.Query("UPDATE", jobsTable)...)
+}
+
+func (t *jobTracing) CancelInProgressByOwner(ctx context.Context, ownerId string, now time.Time) (int64, error) {
+	return otlp.Trace(ctx, otlp.TracerRepository, "JobRepo.CancelInProgressByOwner", func(ctx context.Context) (int64, error) {
+		return t.next.CancelInProgressByOwner(ctx, ownerId, now)
+	}, otlp.Query("UPDATE", jobsTable)...)
+}
+
+func (t *jobTracing) CountActiveByOwner(ctx context.Context, ownerId string, now time.Time) (int64, error) {
+	return otlp.Trace(ctx, otlp.TracerRepository, "JobRepo.CountActiveByOwner", func(ctx context.Context) (int64, error) {
+		return t.next.CountActiveByOwner(ctx, ownerId, now)
+	}, otlp.Query("SELECT", jobsTable)...)
+}
+
+type clientTracing struct {
+	next Client
+}
+
+// NewClientTracing is NewJobTracing for client repositories
+func NewClientTracing(next Client) Client {
+	return &clientTracing{next: next}
+}
+
+func (t *clientTracing) Create(ctx context.Context, req *entity.Client) (*entity.Client, error) {
+	return otlp.Trace(ctx, otlp.TracerRepository, "ClientRepo.Create", func(ctx context.Context) (*entity.Client, error) {
+		return t.next.Create(ctx, req)
+	}, otlp.Query("INSERT", clientsTable)...)
+}
+
+func (t *clientTracing) Get(ctx context.Context, field, value string) (*entity.Client, error) {
+	return otlp.Trace(ctx, otlp.TracerRepository, "ClientRepo.Get", func(ctx context.Context) (*entity.Client, error) {
+		return t.next.Get(ctx, field, value)
+	}, otlp.Query("SELECT", clientsTable)...)
+}
+
+func (t *clientTracing) GetForUpdate(ctx context.Context, field, value string) (*entity.Client, error) {
+	return otlp.Trace(ctx, otlp.TracerRepository, "ClientRepo.GetForUpdate", func(ctx context.Context) (*entity.Client, error) {
+		return t.next.GetForUpdate(ctx, field, value)
+	}, otlp.Query("SELECT", clientsTable)...)
+}
+
+func (t *clientTracing) GetForShare(ctx context.Context, field, value string) (*entity.Client, error) {
+	return otlp.Trace(ctx, otlp.TracerRepository, "ClientRepo.GetForShare", func(ctx context.Context) (*entity.Client, error) {
+		return t.next.GetForShare(ctx, field, value)
+	}, otlp.Query("SELECT", clientsTable)...)
+}
+
+func (t *clientTracing) List(ctx context.Context, req *entity.GetListFilter) ([]*entity.Client, error) {
+	return otlp.Trace(ctx, otlp.TracerRepository, "ClientRepo.List", func(ctx context.Context) ([]*entity.Client, error) {
+		return t.next.List(ctx, req)
+	}, otlp.Query("SELECT", clientsTable)...)
+}
+
+func (t *clientTracing) Update(ctx context.Context, req *entity.Client) error {
+	return otlp.TraceError(ctx, otlp.TracerRepository, "ClientRepo.Update", func(ctx context.Context) error {
+		return t.next.Update(ctx, req)
+	}, otlp.Query("UPDATE", clientsTable)...)
+}
+
+func (t *clientTracing) SetActive(ctx context.Context, field, value string, active bool) error {
+	return otlp.TraceError(ctx, otlp.TracerRepository, "ClientRepo.SetActive", func(ctx context.Context) error {
+		return t.next.SetActive(ctx, field, value, active)
+	}, otlp.Query("UPDATE", clientsTable)...)
+}
+
+func (t *clientTracing) Delete(ctx context.Context, field, value string) error {
+	return otlp.TraceError(ctx, otlp.TracerRepository, "ClientRepo.Delete", func(ctx context.Context) error {
+		return t.next.Delete(ctx, field, value)
+	}, otlp.Query("UPDATE", clientsTable)...)
+}
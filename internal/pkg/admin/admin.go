@@ -0,0 +1,103 @@
+// Package admin serves the operator endpoints of a process on a listener
+// separate from the gRPC and metrics ones: profiles, metrics, build
+// information, the effective configuration, the log level and the state of
+// the broker consumers.
+package admin
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fifth_exam/job_service/internal/pkg/buildinfo"
+	"fifth_exam/job_service/internal/pkg/config"
+	"fifth_exam/job_service/internal/pkg/metrics"
+	"fifth_exam/job_service/internal/usecase/event"
+	"net/http"
+	"net/http/pprof"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	PathPprof     = "/debug/pprof/"
+	PathBuildInfo = "/buildinfo"
+	PathConfig    = "/config"
+	PathLogLevel  = "/log/level"
+	PathConsumers = "/consumers"
+)
+
+// Options are what the admin server exposes
+type Options struct {
+	// Token is required as a bearer token by every endpoint, they are open
+	// when it is empty
+	Token    string
+	Config   *config.Config
+	Gatherer prometheus.Gatherer
+	// Level is read with GET and changed with PUT {"level":"debug"}
+	Level     zap.AtomicLevel
+	Consumers event.BrokerConsumer
+}
+
+// NewServer returns an http server exposing options on addr
+func NewServer(addr string, options Options) *http.Server {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(PathPprof, pprof.Index)
+	mux.HandleFunc(PathPprof+"cmdline", pprof.Cmdline)
+	mux.HandleFunc(PathPprof+"profile", pprof.Profile)
+	mux.HandleFunc(PathPprof+"symbol", pprof.Symbol)
+	mux.HandleFunc(PathPprof+"trace", pprof.Trace)
+
+	mux.Handle(metrics.Path, metrics.Handler(options.Gatherer))
+	mux.Handle(PathLogLevel, options.Level)
+	mux.Handle(PathBuildInfo, jsonHandler(func() interface{} {
+		return buildinfo.Get()
+	}))
+	mux.Handle(PathConfig, jsonHandler(func() interface{} {
+		return options.Config.Entries()
+	}))
+	mux.Handle(PathConsumers, jsonHandler(func() interface{} {
+		if options.Consumers == nil {
+			return []event.ConsumerStatus{}
+		}
+		return options.Consumers.Consumers()
+	}))
+
+	// no write timeout, CPU profiles and traces stream for their duration
+	return &http.Server{
+		Addr:              addr,
+		Handler:           authorize(options.Token, mux),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
+
+// authorize rejects requests without the bearer token, compared in constant
+// time
+func authorize(token string, next http.Handler) http.Handler {
+	if token == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		given, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func jsonHandler(value func() interface{}) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(value())
+	})
+}
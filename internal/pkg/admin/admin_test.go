@@ -0,0 +1,105 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fifth_exam/job_service/internal/infrastructure/inmemory"
+	"fifth_exam/job_service/internal/infrastructure/kafka"
+	"fifth_exam/job_service/internal/pkg/config"
+	"fifth_exam/job_service/internal/pkg/metrics"
+	"fifth_exam/job_service/internal/usecase/event"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type AdminTestSuite struct {
+	suite.Suite
+	Level  zap.AtomicLevel
+	Server *httptest.Server
+}
+
+func (s *AdminTestSuite) SetupTest() {
+	cfg, err := config.Load(nil)
+	s.Suite.Require().NoError(err)
+	cfg.DB.Password = "hunter2"
+
+	consumer := inmemory.NewBroker(1).Consumer()
+	consumer.RegisterConsumer(kafka.NewConsumerConfig([]string{"localhost:9092"}, "job", "job-group",
+		func(ctx context.Context, key, value []byte) error { return nil }))
+
+	s.Level = zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	server := NewServer("", Options{
+		Token:     "admin-token",
+		Config:    cfg,
+		Gatherer:  metrics.NewRegistry(),
+		Level:     s.Level,
+		Consumers: consumer,
+	})
+	s.Server = httptest.NewServer(server.Handler)
+	s.T().Cleanup(s.Server.Close)
+}
+
+func (s *AdminTestSuite) do(method, path, token, body string) *http.Response {
+	req, err := http.NewRequest(method, s.Server.URL+path, strings.NewReader(body))
+	s.Suite.Require().NoError(err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	s.Suite.Require().NoError(err)
+	s.T().Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func (s *AdminTestSuite) TestToken() {
+	for _, path := range []string{PathPprof, metrics.Path, PathBuildInfo, PathConfig, PathLogLevel, PathConsumers} {
+		s.Suite.Equal(http.StatusUnauthorized, s.do(http.MethodGet, path, "", "").StatusCode, path)
+		s.Suite.Equal(http.StatusUnauthorized, s.do(http.MethodGet, path, "wrong", "").StatusCode, path)
+		s.Suite.Equal(http.StatusOK, s.do(http.MethodGet, path, "admin-token", "").StatusCode, path)
+	}
+}
+
+func (s *AdminTestSuite) TestConfigIsRedacted() {
+	var entries []config.Entry
+	s.Suite.NoError(json.NewDecoder(s.do(http.MethodGet, PathConfig, "admin-token", "").Body).Decode(&entries))
+
+	found := false
+	for _, entry := range entries {
+		if entry.Key == "db.password" {
+			found = true
+			s.Suite.NotEqual("hunter2", entry.Value)
+		}
+	}
+	s.Suite.True(found)
+}
+
+func (s *AdminTestSuite) TestLogLevel() {
+	resp := s.do(http.MethodPut, PathLogLevel, "admin-token", `{"level":"debug"}`)
+	s.Suite.Equal(http.StatusOK, resp.StatusCode)
+	s.Suite.Equal(zapcore.DebugLevel, s.Level.Level())
+
+	resp = s.do(http.MethodPut, PathLogLevel, "admin-token", `{"level":"loud"}`)
+	s.Suite.Equal(http.StatusBadRequest, resp.StatusCode)
+	s.Suite.Equal(zapcore.DebugLevel, s.Level.Level())
+}
+
+func (s *AdminTestSuite) TestConsumers() {
+	var statuses []event.ConsumerStatus
+	s.Suite.NoError(json.NewDecoder(s.do(http.MethodGet, PathConsumers, "admin-token", "").Body).Decode(&statuses))
+	s.Suite.Require().Len(statuses, 1)
+	s.Suite.Equal("job", statuses[0].Topic)
+	s.Suite.Equal("job-group", statuses[0].GroupID)
+	s.Suite.False(statuses[0].Running)
+
+	s.Suite.Equal(http.StatusMethodNotAllowed, s.do(http.MethodPost, PathConsumers, "admin-token", "").StatusCode)
+}
+
+func TestAdminTestSuite(t *testing.T) {
+	suite.Run(t, new(AdminTestSuite))
+}
@@ -0,0 +1,44 @@
+// Package auth verifies the JWTs callers present and carries their identity
+// through the request context
+package auth
+
+import "context"
+
+// RoleAdmin may act on any job regardless of its owner and on any client
+const RoleAdmin = "admin"
+
+// Identity is the authenticated caller, Subject is the id of the client the
+// token was issued to
+type Identity struct {
+	Subject string
+	Roles   []string
+}
+
+// HasRole reports whether the identity was granted role
+func (i *Identity) HasRole(role string) bool {
+	for _, r := range i.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAdmin reports whether the identity has the admin role
+func (i *Identity) IsAdmin() bool {
+	return i.HasRole(RoleAdmin)
+}
+
+type identityKey struct{}
+
+// WithIdentity returns a copy of ctx carrying the identity
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// FromContext returns the identity of the caller, ok is false for requests
+// that were not authenticated
+func FromContext(ctx context.Context) (identity *Identity, ok bool) {
+	identity, ok = ctx.Value(identityKey{}).(*Identity)
+	return identity, ok && identity != nil
+}
@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	RoleClient     = "client"
+	RoleFreelancer = "freelancer"
+	RoleModerator  = "moderator"
+
+	// PermissionAll granted to a role grants every permission
+	PermissionAll = "*"
+)
+
+var ErrPermissionDenied = errors.New("permission denied")
+
+// Policy decides which RPCs a caller may invoke. Roles grant permissions and
+// every RPC, identified by its full method name such as
+// "/job.JobService/Delete", requires all of its permissions. RPCs missing
+// from the policy are denied, RPCs listed without permissions only require
+// an authenticated caller.
+type Policy struct {
+	roles   map[string]map[string]bool
+	methods map[string][]string
+}
+
+// NewPolicy builds a policy from the permissions of every role and the
+// permissions required by every method
+func NewPolicy(roles, methods map[string][]string) (*Policy, error) {
+	p := &Policy{
+		roles:   make(map[string]map[string]bool, len(roles)),
+		methods: make(map[string][]string, len(methods)),
+	}
+
+	for role, permissions := range roles {
+		granted := make(map[string]bool, len(permissions))
+		for _, permission := range permissions {
+			granted[permission] = true
+		}
+		p.roles[role] = granted
+	}
+
+	var invalid []string
+	for method, permissions := range methods {
+		if !ValidMethod(method) {
+			invalid = append(invalid, method)
+			continue
+		}
+		p.methods[method] = permissions
+	}
+	if len(invalid) != 0 {
+		sort.Strings(invalid)
+		return nil, fmt.Errorf("invalid method names %s, expected /package.Service/Method", strings.Join(invalid, ", "))
+	}
+
+	return p, nil
+}
+
+// Authorize returns nil when identity may call method, otherwise an error
+// wrapping ErrPermissionDenied that names the missing permissions
+func (p *Policy) Authorize(identity *Identity, method string) error {
+	required, ok := p.methods[method]
+	if !ok {
+		return fmt.Errorf("%w: %s is not allowed by the policy", ErrPermissionDenied, method)
+	}
+
+	var missing []string
+	for _, permission := range required {
+		if !p.Granted(identity, permission) {
+			missing = append(missing, permission)
+		}
+	}
+	if len(missing) != 0 {
+		return fmt.Errorf("%w: %s requires %s", ErrPermissionDenied, method, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// Granted reports whether any role of identity grants permission
+func (p *Policy) Granted(identity *Identity, permission string) bool {
+	if identity == nil {
+		return false
+	}
+	for _, role := range identity.Roles {
+		granted := p.roles[role]
+		if granted[permission] || granted[PermissionAll] {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidMethod reports whether method is a full gRPC method name
+func ValidMethod(method string) bool {
+	service, name, ok := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	return strings.HasPrefix(method, "/") && ok && service != "" && name != "" && !strings.Contains(name, "/")
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PolicyTestSuite struct {
+	suite.Suite
+	Policy *Policy
+}
+
+func (s *PolicyTestSuite) SetupTest() {
+	policy, err := NewPolicy(
+		map[string][]string{
+			RoleAdmin:      {PermissionAll},
+			RoleClient:     {"jobs:read", "jobs:delete"},
+			RoleFreelancer: {"jobs:read"},
+		},
+		map[string][]string{
+			"/job.JobService/Get":     {"jobs:read"},
+			"/job.JobService/Delete":  {"jobs:delete"},
+			"/job.JobService/Publish": {"jobs:read", "jobs:publish"},
+			"/job.JobService/Ping":    nil,
+		},
+	)
+	s.Suite.Require().NoError(err)
+	s.Policy = policy
+}
+
+func (s *PolicyTestSuite) TestAuthorize() {
+	client := &Identity{Subject: "c", Roles: []string{RoleClient}}
+	freelancer := &Identity{Subject: "f", Roles: []string{RoleFreelancer}}
+	admin := &Identity{Subject: "a", Roles: []string{RoleAdmin}}
+	nobody := &Identity{Subject: "n"}
+
+	s.Suite.NoError(s.Policy.Authorize(client, "/job.JobService/Delete"))
+	s.Suite.NoError(s.Policy.Authorize(freelancer, "/job.JobService/Get"))
+	s.Suite.ErrorIs(s.Policy.Authorize(freelancer, "/job.JobService/Delete"), ErrPermissionDenied)
+
+	// every required permission must be granted
+	err := s.Policy.Authorize(client, "/job.JobService/Publish")
+	s.Suite.ErrorIs(err, ErrPermissionDenied)
+	s.Suite.ErrorContains(err, "jobs:publish")
+	s.Suite.NoError(s.Policy.Authorize(admin, "/job.JobService/Publish"))
+
+	// methods without permissions only need an authenticated caller
+	s.Suite.NoError(s.Policy.Authorize(nobody, "/job.JobService/Ping"))
+
+	// methods missing from the policy are denied, even to admins
+	s.Suite.ErrorIs(s.Policy.Authorize(admin, "/job.JobService/Unknown"), ErrPermissionDenied)
+	s.Suite.ErrorIs(s.Policy.Authorize(nil, "/job.JobService/Get"), ErrPermissionDenied)
+}
+
+func (s *PolicyTestSuite) TestInvalidMethod() {
+	_, err := NewPolicy(nil, map[string][]string{"job.JobService.Get": nil, "/job.JobService/": nil})
+	s.Suite.ErrorContains(err, "job.JobService.Get")
+	s.Suite.ErrorContains(err, "/job.JobService/")
+}
+
+func TestPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(PolicyTestSuite))
+}
@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrNoKey        = errors.New("no token verification key configured")
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// Config holds the locally configured verification keys, at least one of
+// HMACSecret and RSAPublicKey (PEM) is required
+type Config struct {
+	HMACSecret   string
+	RSAPublicKey string
+	Issuer       string
+	Audience     string
+	RolesClaim   string
+	// Leeway tolerates clock skew when checking exp, nbf and iat
+	Leeway time.Duration
+}
+
+// Verifier checks HS256/384/512 tokens against the shared secret and
+// RS256/384/512 tokens against the public key
+type Verifier struct {
+	hmacSecret []byte
+	publicKey  *rsa.PublicKey
+	rolesClaim string
+	parser     *jwt.Parser
+}
+
+func NewVerifier(config Config) (*Verifier, error) {
+	v := &Verifier{rolesClaim: config.RolesClaim}
+	if v.rolesClaim == "" {
+		v.rolesClaim = "roles"
+	}
+
+	var methods []string
+	if config.HMACSecret != "" {
+		v.hmacSecret = []byte(config.HMACSecret)
+		methods = append(methods, "HS256", "HS384", "HS512")
+	}
+	if config.RSAPublicKey != "" {
+		key, err := jwt.ParseRSAPublicKeyFromPEM([]byte(config.RSAPublicKey))
+		if err != nil {
+			return nil, fmt.Errorf("parse rsa public key: %w", err)
+		}
+		v.publicKey = key
+		methods = append(methods, "RS256", "RS384", "RS512")
+	}
+	if len(methods) == 0 {
+		return nil, ErrNoKey
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(config.Leeway),
+	}
+	if config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(config.Issuer))
+	}
+	if config.Audience != "" {
+		options = append(options, jwt.WithAudience(config.Audience))
+	}
+	v.parser = jwt.NewParser(options...)
+
+	return v, nil
+}
+
+// Verify checks the signature and claims of the token and returns the
+// identity it was issued to
+func (v *Verifier) Verify(token string) (*Identity, error) {
+	claims := jwt.MapClaims{}
+	if _, err := v.parser.ParseWithClaims(token, claims, v.key); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return nil, fmt.Errorf("%w: sub claim is required", ErrInvalidToken)
+	}
+
+	identity := &Identity{Subject: subject}
+	switch roles := claims[v.rolesClaim].(type) {
+	case nil:
+	case string:
+		identity.Roles = []string{roles}
+	case []interface{}:
+		for _, role := range roles {
+			if r, ok := role.(string); ok {
+				identity.Roles = append(identity.Roles, r)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s claim must be a string or a list of strings", ErrInvalidToken, v.rolesClaim)
+	}
+
+	return identity, nil
+}
+
+func (v *Verifier) key(token *jwt.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if v.hmacSecret != nil {
+			return v.hmacSecret, nil
+		}
+	case *jwt.SigningMethodRSA:
+		if v.publicKey != nil {
+			return v.publicKey, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+}
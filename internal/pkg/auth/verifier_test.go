@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/suite"
+)
+
+const secret = "test-secret"
+
+type VerifierTestSuite struct {
+	suite.Suite
+}
+
+func claims(subject string, roles interface{}, expires time.Duration) jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":   subject,
+		"roles": roles,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(expires).Unix(),
+	}
+}
+
+func sign(method jwt.SigningMethod, key interface{}, claims jwt.MapClaims) string {
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		panic(err)
+	}
+	return token
+}
+
+func (s *VerifierTestSuite) TestHMAC() {
+	verifier, err := NewVerifier(Config{HMACSecret: secret})
+	s.Suite.Require().NoError(err)
+
+	identity, err := verifier.Verify(sign(jwt.SigningMethodHS256, []byte(secret), claims("client-1", []string{"admin"}, time.Minute)))
+	s.Suite.NoError(err)
+	s.Suite.Equal("client-1", identity.Subject)
+	s.Suite.True(identity.IsAdmin())
+
+	_, err = verifier.Verify(sign(jwt.SigningMethodHS256, []byte("other"), claims("client-1", nil, time.Minute)))
+	s.Suite.ErrorIs(err, ErrInvalidToken)
+
+	_, err = verifier.Verify(sign(jwt.SigningMethodHS256, []byte(secret), claims("client-1", nil, -time.Minute)))
+	s.Suite.ErrorIs(err, ErrInvalidToken)
+
+	_, err = verifier.Verify(sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, claims("client-1", nil, time.Minute)))
+	s.Suite.ErrorIs(err, ErrInvalidToken)
+
+	_, err = verifier.Verify(sign(jwt.SigningMethodHS256, []byte(secret), claims("", nil, time.Minute)))
+	s.Suite.ErrorIs(err, ErrInvalidToken)
+}
+
+func (s *VerifierTestSuite) TestRSA() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Suite.Require().NoError(err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	s.Suite.Require().NoError(err)
+	public := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+
+	verifier, err := NewVerifier(Config{RSAPublicKey: string(public), Issuer: "auth-service"})
+	s.Suite.Require().NoError(err)
+
+	c := claims("client-2", "manager", time.Minute)
+	c["iss"] = "auth-service"
+	identity, err := verifier.Verify(sign(jwt.SigningMethodRS256, key, c))
+	s.Suite.NoError(err)
+	s.Suite.Equal([]string{"manager"}, identity.Roles)
+	s.Suite.False(identity.IsAdmin())
+
+	// an HMAC token must not be accepted when only the RSA key is configured
+	_, err = verifier.Verify(sign(jwt.SigningMethodHS256, public, c))
+	s.Suite.ErrorIs(err, ErrInvalidToken)
+
+	c["iss"] = "someone-else"
+	_, err = verifier.Verify(sign(jwt.SigningMethodRS256, key, c))
+	s.Suite.ErrorIs(err, ErrInvalidToken)
+}
+
+func (s *VerifierTestSuite) TestNoKey() {
+	_, err := NewVerifier(Config{})
+	s.Suite.ErrorIs(err, ErrNoKey)
+}
+
+func TestVerifierTestSuite(t *testing.T) {
+	suite.Run(t, new(VerifierTestSuite))
+}
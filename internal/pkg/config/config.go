@@ -1,8 +1,11 @@
 package config
 
 import (
+	"fifth_exam/job_service/internal/pkg/app"
+	"net"
+	"net/url"
 	"os"
-	"strings"
+	"time"
 )
 
 type Config struct {
@@ -11,8 +14,28 @@ type Config struct {
 	LogLevel    string
 	RPCPort     string
 
+	ShutdownTimeout time.Duration
+
+	Log struct {
+		// Outputs are stdout, stderr and file, the file is <app>.log in Dir
+		// and <app>_cli.log for the consumer
+		Outputs []string
+		Dir     string
+		// MaxSizeMB and RotateInterval rotate the file, MaxBackups and MaxAge
+		// bound the rotated files kept, zero disables a limit
+		MaxSizeMB      int64
+		MaxBackups     int64
+		MaxAge         time.Duration
+		RotateInterval time.Duration
+	}
+
 	Context struct {
-		Timeout string
+		Timeout time.Duration
+	}
+
+	Health struct {
+		CheckInterval time.Duration
+		DrainDelay    time.Duration
 	}
 
 	DB struct {
@@ -22,53 +45,337 @@ type Config struct {
 		User     string
 		Password string
 		SslMode  string
+		// DSN, when set, is used as is instead of the fields above
+		DSN string
+		// AutoMigrate applies the embedded migrations when the server starts
+		AutoMigrate bool
+	}
+
+	AccessLog struct {
+		Enabled bool
+		// Payloads logs requests and responses with RedactFields masked
+		Payloads     bool
+		RedactFields []string
+	}
+
+	Auth struct {
+		Enabled      bool
+		HMACSecret   string
+		RSAPublicKey string
+		Issuer       string
+		Audience     string
+		RolesClaim   string
+		Leeway       time.Duration
+	}
+
+	RBAC struct {
+		// Roles maps every role to the permissions it grants, "*" grants all
+		Roles map[string][]string
+		// Methods maps full gRPC method names to the permissions they require
+		Methods map[string][]string
+	}
+
+	RateLimit struct {
+		Enabled bool
+		// Default is the quota of a caller across the methods without their
+		// own, a rate such as 50/s optionally followed by the burst
+		Default []string
+		// Methods gives full gRPC method names their own quota per caller
+		Methods     map[string][]string
+		IdleTimeout time.Duration
+	}
+
+	List struct {
+		// MaxLimit is the largest page a list RPC returns, it is also the
+		// page size of requests without a limit
+		MaxLimit int64
+	}
+
+	Client struct {
+		// DeletePolicy is cascade or block, see usecase.ClientDeleteCascade
+		DeletePolicy string
 	}
 
 	OTLPCollector struct {
 		Host string
 		Port string
+		// Insecure disables TLS, CAFile verifies the collector with a custom
+		// CA instead of the system roots
+		Insecure bool
+		CAFile   string
+	}
+
+	Tracing struct {
+		// Exporter is otlp-grpc, otlp-http, stdout or none, spans are still
+		// created for log correlation with none
+		Exporter string
+		// Sampler is always_on, always_off or ratio, with ParentBased the
+		// decision of a remote parent is kept
+		Sampler     string
+		SampleRatio float64
+		ParentBased bool
+	}
+
+	Metrics struct {
+		Port         string
+		ConsumerPort string
+	}
+
+	Gateway struct {
+		// Enabled serves JobService as JSON over HTTP on Port, the gateway
+		// calls the gRPC server on RPCPort
+		Enabled bool
+		Port    string
+		// CORSOrigins are the browser origins allowed to call the gateway,
+		// "*" allows any
+		CORSOrigins []string
+	}
+
+	Admin struct {
+		Enabled bool
+		// Port and ConsumerPort are the admin listen addresses of the gRPC
+		// server and of the consumer
+		Port         string
+		ConsumerPort string
+		Token        string
 	}
 
 	Kafka struct {
-		Address []string
-		Topic   struct {
+		Address       []string
+		ConsumerGroup string
+		Topic         struct {
 			JobTopic string
 		}
 	}
+
+	// sources records where the value of every key was taken from
+	sources map[string]string
+}
+
+// profiles are the built-in overlays applied on top of the defaults for the
+// environment, the configuration file can extend them under "profiles". The
+// defaults already target develop.
+var profiles = map[string]map[string]string{
+	app.EnvironmentDevelop: {},
+	app.EnvironmentProduction: {
+		"log_level":    "info",
+		"db.sslmode":   "require",
+		"auth.enabled": "true",
+	},
 }
 
+// New returns the default configuration with the profile of ENVIRONMENT
+// applied and overridden by environment variables, variables holding invalid
+// values are ignored. Use Load to also read the configuration file and flags
+// and to get errors reported.
 func New() *Config {
+	config := defaults()
+	if environment, ok := os.LookupEnv("ENVIRONMENT"); ok {
+		_ = config.applyValues(profiles[environment], SourceProfile)
+	}
+	_ = config.applyEnv()
+	return config
+}
+
+// PostgresDSN returns db.dsn when it is set, otherwise a postgres URL
+// composed from the other db keys
+func (c *Config) PostgresDSN() string {
+	if c.DB.DSN != "" {
+		return c.DB.DSN
+	}
+
+	dsn := url.URL{
+		Scheme: "postgres",
+		Host:   net.JoinHostPort(c.DB.Host, c.DB.Port),
+		Path:   "/" + c.DB.Name,
+	}
+	if c.DB.Password != "" {
+		dsn.User = url.UserPassword(c.DB.User, c.DB.Password)
+	} else if c.DB.User != "" {
+		dsn.User = url.User(c.DB.User)
+	}
+	if c.DB.SslMode != "" {
+		dsn.RawQuery = url.Values{"sslmode": {c.DB.SslMode}}.Encode()
+	}
+	return dsn.String()
+}
+
+func defaults() *Config {
 	var config Config
+	config.sources = make(map[string]string)
 
 	// general configuration
-	config.APP = getEnv("APP", "job_service")
-	config.Environment = getEnv("ENVIRONMENT", "develop")
-	config.LogLevel = getEnv("LOG_LEVEL", "debug")
-	config.RPCPort = getEnv("RPC_PORT", ":9090")
-	config.Context.Timeout = getEnv("CONTEXT_TIMEOUT", "30s")
+	config.APP = "job_service"
+	config.Environment = "develop"
+	config.LogLevel = "debug"
+	config.RPCPort = ":9090"
+	config.ShutdownTimeout = 15 * time.Second
+
+	// log configuration
+	config.Log.Outputs = []string{"stdout", "file"}
+	config.Log.Dir = "."
+	config.Log.MaxSizeMB = 100
+	config.Log.MaxBackups = 10
+	config.Log.MaxAge = 7 * 24 * time.Hour
+	config.Log.RotateInterval = 24 * time.Hour
+	config.Context.Timeout = 30 * time.Second
+
+	// health configuration
+	config.Health.CheckInterval = 5 * time.Second
+	config.Health.DrainDelay = 5 * time.Second
 
 	// db configuration
-	config.DB.Host = getEnv("POSTGRES_HOST", "localhost")
-	config.DB.Port = getEnv("POSTGRES_PORT", "5432")
-	config.DB.User = getEnv("POSTGRES_USER", "postgres")
-	config.DB.Password = getEnv("POSTGRES_PASSWORD", "mubina2007")
-	config.DB.SslMode = getEnv("POSTGRES_SSLMODE", "disable")
-	config.DB.Name = getEnv("POSTGRES_DATABASE", "companydb")
+	config.DB.Host = "localhost"
+	config.DB.Port = "5432"
+	config.DB.User = "postgres"
+	config.DB.SslMode = "disable"
+	config.DB.Name = "companydb"
+
+	// access log configuration
+	config.AccessLog.Enabled = true
+	config.AccessLog.RedactFields = []string{"password", "token", "secret", "authorization", "email", "phone", "address"}
 
-	config.OTLPCollector.Host = getEnv("OTLP_COLLECTOR_HOST", "0.0.0.0")
-	config.OTLPCollector.Port = getEnv("OTLP_COLLECTOR_PORT", ":4317")
+	// auth configuration, develop runs without authentication
+	config.Auth.RolesClaim = "roles"
+	config.Auth.Leeway = 30 * time.Second
+
+	// rbac configuration, applied when auth is enabled. clients:update lets
+	// everyone but admins update only their own client
+	config.RBAC.Roles = map[string][]string{
+		"admin":      {"*"},
+		"moderator":  {"jobs:read", "clients:read", "clients:update"},
+		"client":     {"jobs:read", "jobs:create", "jobs:update", "jobs:delete", "clients:read", "clients:update"},
+		"freelancer": {"jobs:read", "clients:read"},
+	}
+	config.RBAC.Methods = map[string][]string{
+		"/job.JobService/Create":           {"jobs:create"},
+		"/job.JobService/Get":              {"jobs:read"},
+		"/job.JobService/GetList":          {"jobs:read"},
+		"/job.JobService/ListJobsByOwner":  {"jobs:read"},
+		"/job.JobService/Update":           {"jobs:update"},
+		"/job.JobService/Delete":           {"jobs:delete"},
+		"/client.ClientService/Create":     {"clients:create"},
+		"/client.ClientService/Get":        {"clients:read"},
+		"/client.ClientService/GetList":    {"clients:read"},
+		"/client.ClientService/Update":     {"clients:update"},
+		"/client.ClientService/Activate":   {"clients:activate"},
+		"/client.ClientService/Deactivate": {"clients:activate"},
+		"/client.ClientService/Delete":     {"clients:delete"},
+	}
+
+	// rate limit configuration
+	config.RateLimit.Enabled = true
+	config.RateLimit.Default = []string{"50/s", "100"}
+	config.RateLimit.Methods = map[string][]string{
+		"/job.JobService/GetList":         {"5/s", "10"},
+		"/job.JobService/ListJobsByOwner": {"5/s", "10"},
+		"/client.ClientService/GetList":   {"5/s", "10"},
+	}
+	config.RateLimit.IdleTimeout = 10 * time.Minute
+	config.List.MaxLimit = 100
+
+	// client configuration
+	config.Client.DeletePolicy = "cascade"
+
+	config.OTLPCollector.Host = "0.0.0.0"
+	config.OTLPCollector.Port = ":4317"
+	config.OTLPCollector.Insecure = true
+
+	// tracing configuration
+	config.Tracing.Exporter = "otlp-grpc"
+	config.Tracing.Sampler = "always_on"
+	config.Tracing.SampleRatio = 1
+	config.Tracing.ParentBased = true
+
+	// metrics configuration
+	config.Metrics.Port = ":9100"
+	config.Metrics.ConsumerPort = ":9101"
+
+	// gateway configuration, loopback only like admin, put it behind a proxy
+	// or configure its port to expose it
+	config.Gateway.Enabled = true
+	config.Gateway.Port = "127.0.0.1:8080"
+
+	// admin configuration, loopback only unless configured otherwise
+	config.Admin.Enabled = true
+	config.Admin.Port = "127.0.0.1:6060"
+	config.Admin.ConsumerPort = "127.0.0.1:6061"
 
 	// kafka configuration
-	config.Kafka.Address = strings.Split(getEnv("KAFKA_ADDRESS", "localhost:9092"), ",")
-	config.Kafka.Topic.JobTopic = getEnv("KAFKA_TOPIC_JOB_SERVICE", "job.service.create")
+	config.Kafka.Address = []string{"localhost:9092"}
+	config.Kafka.ConsumerGroup = "1"
+	config.Kafka.Topic.JobTopic = "job.service.create"
+
+	for _, f := range config.fields() {
+		config.sources[f.key] = SourceDefault
+	}
 
 	return &config
 }
 
-func getEnv(key string, defaultVaule string) string {
-	_, exists := os.LookupEnv(key)
-	if exists {
-		return defaultVaule
+// fields lists every configuration key with the environment variable and
+// flag it can be set from
+func (c *Config) fields() []field {
+	return []field{
+		{key: "app", env: "APP", value: &c.APP, usage: "application name"},
+		{key: "environment", env: "ENVIRONMENT", value: &c.Environment, usage: "develop or production"},
+		{key: "log_level", env: "LOG_LEVEL", value: &c.LogLevel, usage: "zap log level"},
+		{key: "log.outputs", env: "LOG_OUTPUTS", value: &c.Log.Outputs, usage: "log outputs: stdout, stderr and file"},
+		{key: "log.dir", env: "LOG_DIR", value: &c.Log.Dir, usage: "directory of the log file"},
+		{key: "log.max_size_mb", env: "LOG_MAX_SIZE_MB", value: &c.Log.MaxSizeMB, usage: "size in MB the log file is rotated at, 0 disables"},
+		{key: "log.max_backups", env: "LOG_MAX_BACKUPS", value: &c.Log.MaxBackups, usage: "rotated log files kept, 0 keeps all"},
+		{key: "log.max_age", env: "LOG_MAX_AGE", value: &c.Log.MaxAge, usage: "age rotated log files are removed at, 0 keeps them"},
+		{key: "log.rotate_interval", env: "LOG_ROTATE_INTERVAL", value: &c.Log.RotateInterval, usage: "interval the log file is rotated at, 0 disables"},
+		{key: "rpc_port", env: "RPC_PORT", value: &c.RPCPort, usage: "gRPC listen address"},
+		{key: "shutdown_timeout", env: "SHUTDOWN_TIMEOUT", value: &c.ShutdownTimeout, usage: "time given to in-flight requests on shutdown"},
+		{key: "context.timeout", env: "CONTEXT_TIMEOUT", value: &c.Context.Timeout, usage: "usecase context timeout"},
+		{key: "health.check_interval", env: "HEALTH_CHECK_INTERVAL", value: &c.Health.CheckInterval, usage: "interval of dependency health checks"},
+		{key: "health.drain_delay", env: "HEALTH_DRAIN_DELAY", value: &c.Health.DrainDelay, usage: "time between reporting NOT_SERVING and stopping"},
+		{key: "db.host", env: "POSTGRES_HOST", value: &c.DB.Host, usage: "postgres host"},
+		{key: "db.port", env: "POSTGRES_PORT", value: &c.DB.Port, usage: "postgres port"},
+		{key: "db.name", env: "POSTGRES_DATABASE", value: &c.DB.Name, usage: "postgres database"},
+		{key: "db.user", env: "POSTGRES_USER", value: &c.DB.User, usage: "postgres user"},
+		{key: "db.password", env: "POSTGRES_PASSWORD", value: &c.DB.Password, usage: "postgres password", secret: true},
+		{key: "db.sslmode", env: "POSTGRES_SSLMODE", value: &c.DB.SslMode, usage: "postgres sslmode"},
+		{key: "db.dsn", env: "POSTGRES_DSN", value: &c.DB.DSN, usage: "full postgres connection string, overrides the other db keys", secret: true},
+		{key: "db.auto_migrate", env: "DB_AUTO_MIGRATE", value: &c.DB.AutoMigrate, usage: "apply pending migrations on startup"},
+		{key: "access_log.enabled", env: "ACCESS_LOG_ENABLED", value: &c.AccessLog.Enabled, usage: "log one line per RPC"},
+		{key: "access_log.payloads", env: "ACCESS_LOG_PAYLOADS", value: &c.AccessLog.Payloads, usage: "log request and response payloads"},
+		{key: "access_log.redact_fields", env: "ACCESS_LOG_REDACT_FIELDS", value: &c.AccessLog.RedactFields, usage: "comma separated payload fields whose values are masked"},
+		{key: "auth.enabled", env: "AUTH_ENABLED", value: &c.Auth.Enabled, usage: "require a JWT on every RPC"},
+		{key: "auth.hmac_secret", env: "AUTH_HMAC_SECRET", value: &c.Auth.HMACSecret, usage: "shared secret of HS256/384/512 tokens", secret: true},
+		{key: "auth.rsa_public_key", env: "AUTH_RSA_PUBLIC_KEY", value: &c.Auth.RSAPublicKey, usage: "PEM public key of RS256/384/512 tokens"},
+		{key: "auth.issuer", env: "AUTH_ISSUER", value: &c.Auth.Issuer, usage: "required iss claim, empty accepts any"},
+		{key: "auth.audience", env: "AUTH_AUDIENCE", value: &c.Auth.Audience, usage: "required aud claim, empty accepts any"},
+		{key: "auth.roles_claim", env: "AUTH_ROLES_CLAIM", value: &c.Auth.RolesClaim, usage: "claim holding the roles of the caller"},
+		{key: "auth.leeway", env: "AUTH_LEEWAY", value: &c.Auth.Leeway, usage: "clock skew tolerated on exp, nbf and iat"},
+		{key: "rbac.roles", env: "RBAC_ROLES", value: &c.RBAC.Roles, usage: "permissions of every role as role=perm,perm;role=perm"},
+		{key: "rbac.methods", env: "RBAC_METHODS", value: &c.RBAC.Methods, usage: "permissions required by full gRPC method names as /pkg.Service/Method=perm;..."},
+		{key: "rate_limit.enabled", env: "RATE_LIMIT_ENABLED", value: &c.RateLimit.Enabled, usage: "limit the rate of RPCs per caller"},
+		{key: "rate_limit.default", env: "RATE_LIMIT_DEFAULT", value: &c.RateLimit.Default, usage: "quota of a caller as rate[,burst], e.g. 50/s,100"},
+		{key: "rate_limit.methods", env: "RATE_LIMIT_METHODS", value: &c.RateLimit.Methods, usage: "quotas of single methods as /pkg.Service/Method=rate,burst;..."},
+		{key: "rate_limit.idle_timeout", env: "RATE_LIMIT_IDLE_TIMEOUT", value: &c.RateLimit.IdleTimeout, usage: "time after which the bucket of an idle caller is dropped"},
+		{key: "list.max_limit", env: "LIST_MAX_LIMIT", value: &c.List.MaxLimit, usage: "largest page size of list RPCs"},
+		{key: "client.delete_policy", env: "CLIENT_DELETE_POLICY", value: &c.Client.DeletePolicy, usage: "what deleting a client does to its jobs: cascade or block"},
+		{key: "otlp_collector.host", env: "OTLP_COLLECTOR_HOST", value: &c.OTLPCollector.Host, usage: "otlp collector host"},
+		{key: "otlp_collector.port", env: "OTLP_COLLECTOR_PORT", value: &c.OTLPCollector.Port, usage: "otlp collector port"},
+		{key: "otlp_collector.insecure", env: "OTLP_COLLECTOR_INSECURE", value: &c.OTLPCollector.Insecure, usage: "connect to the collector without TLS"},
+		{key: "otlp_collector.ca_file", env: "OTLP_COLLECTOR_CA_FILE", value: &c.OTLPCollector.CAFile, usage: "PEM CA bundle verifying the collector, system roots when empty"},
+		{key: "tracing.exporter", env: "TRACING_EXPORTER", value: &c.Tracing.Exporter, usage: "span exporter: otlp-grpc, otlp-http, stdout or none"},
+		{key: "tracing.sampler", env: "TRACING_SAMPLER", value: &c.Tracing.Sampler, usage: "sampler: always_on, always_off or ratio"},
+		{key: "tracing.sample_ratio", env: "TRACING_SAMPLE_RATIO", value: &c.Tracing.SampleRatio, usage: "fraction of traces sampled by the ratio sampler"},
+		{key: "tracing.parent_based", env: "TRACING_PARENT_BASED", value: &c.Tracing.ParentBased, usage: "follow the sampling decision of the caller"},
+		{key: "metrics.port", env: "METRICS_PORT", value: &c.Metrics.Port, usage: "metrics listen address of the gRPC server"},
+		{key: "metrics.consumer_port", env: "CONSUMER_METRICS_PORT", value: &c.Metrics.ConsumerPort, usage: "metrics listen address of the consumer"},
+		{key: "gateway.enabled", env: "GATEWAY_ENABLED", value: &c.Gateway.Enabled, usage: "serve the REST/JSON gateway of JobService"},
+		{key: "gateway.port", env: "GATEWAY_PORT", value: &c.Gateway.Port, usage: "REST/JSON gateway listen address"},
+		{key: "gateway.cors_origins", env: "GATEWAY_CORS_ORIGINS", value: &c.Gateway.CORSOrigins, usage: "origins allowed to call the gateway from a browser"},
+		{key: "admin.enabled", env: "ADMIN_ENABLED", value: &c.Admin.Enabled, usage: "serve pprof, metrics, build info, config, log level and consumers"},
+		{key: "admin.port", env: "ADMIN_PORT", value: &c.Admin.Port, usage: "admin listen address of the gRPC server"},
+		{key: "admin.consumer_port", env: "CONSUMER_ADMIN_PORT", value: &c.Admin.ConsumerPort, usage: "admin listen address of the consumer"},
+		{key: "admin.token", env: "ADMIN_TOKEN", value: &c.Admin.Token, usage: "bearer token required by the admin endpoints", secret: true},
+		{key: "kafka.address", env: "KAFKA_ADDRESS", value: &c.Kafka.Address, usage: "comma separated kafka brokers"},
+		{key: "kafka.consumer_group", env: "KAFKA_CONSUMER_GROUP", value: &c.Kafka.ConsumerGroup, usage: "kafka consumer group of the job consumer"},
+		{key: "kafka.topic.job_topic", env: "KAFKA_TOPIC_JOB_SERVICE", value: &c.Kafka.Topic.JobTopic, usage: "kafka topic of job events"},
 	}
-	return defaultVaule
 }
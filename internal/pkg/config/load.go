@@ -0,0 +1,430 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	SourceDefault    = "default"
+	SourceProfile    = "profile"
+	SourceFile       = "file"
+	SourceEnv        = "env"
+	SourceSecretFile = "secret_file"
+	SourceFlag       = "flag"
+
+	// FlagConfig is the flag holding the path of the configuration file,
+	// CONFIG_FILE is used when it is not set
+	FlagConfig = "config"
+	EnvConfig  = "CONFIG_FILE"
+
+	// fileSuffix is appended to the environment variable of a key to read its
+	// value from a file, e.g. POSTGRES_PASSWORD_FILE
+	fileSuffix = "_FILE"
+
+	profilesKey = "profiles"
+)
+
+type field struct {
+	key    string
+	env    string
+	usage  string
+	secret bool
+	// value points to a string, []string, bool, int64, float64,
+	// time.Duration or map[string][]string of the Config
+	value interface{}
+}
+
+func (f field) flag() string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(f.key)
+}
+
+func (f field) set(raw string) error {
+	switch v := f.value.(type) {
+	case *string:
+		*v = raw
+	case *[]string:
+		*v = splitList(raw)
+	case *bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.key, err)
+		}
+		*v = b
+	case *int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.key, err)
+		}
+		*v = n
+	case *float64:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.key, err)
+		}
+		*v = n
+	case *time.Duration:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.key, err)
+		}
+		*v = d
+	case *map[string][]string:
+		m := make(map[string][]string)
+		for _, entry := range strings.Split(raw, ";") {
+			if entry = strings.TrimSpace(entry); entry == "" {
+				continue
+			}
+			name, items, ok := strings.Cut(entry, "=")
+			if !ok {
+				return fmt.Errorf("%s: entry %q must be name=value,value", f.key, entry)
+			}
+			m[strings.TrimSpace(name)] = splitList(items)
+		}
+		*v = m
+	default:
+		return fmt.Errorf("%s: unsupported config type %T", f.key, f.value)
+	}
+	return nil
+}
+
+func (f field) String() string {
+	switch v := f.value.(type) {
+	case *string:
+		return *v
+	case *[]string:
+		return strings.Join(*v, ",")
+	case *bool:
+		return strconv.FormatBool(*v)
+	case *int64:
+		return strconv.FormatInt(*v, 10)
+	case *float64:
+		return strconv.FormatFloat(*v, 'g', -1, 64)
+	case *time.Duration:
+		return v.String()
+	case *map[string][]string:
+		names := make([]string, 0, len(*v))
+		for name := range *v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		entries := make([]string, 0, len(names))
+		for _, name := range names {
+			entries = append(entries, name+"="+strings.Join((*v)[name], ","))
+		}
+		return strings.Join(entries, ";")
+	}
+	return ""
+}
+
+// setEntry sets a single entry of a map field, the configuration file gives
+// maps as nested keys such as rbac.roles.admin
+func (f field) setEntry(name, raw string) error {
+	m, ok := f.value.(*map[string][]string)
+	if !ok {
+		return fmt.Errorf("%s: not a map", f.key)
+	}
+	if *m == nil {
+		*m = make(map[string][]string)
+	}
+	(*m)[name] = splitList(raw)
+	return nil
+}
+
+func splitList(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// RegisterFlags adds the --config flag and one flag per configuration key to
+// the flag set, e.g. --db-host or --context-timeout
+func RegisterFlags(flags *pflag.FlagSet) {
+	flags.String(FlagConfig, "", "path of a YAML or TOML configuration file (env "+EnvConfig+")")
+	for _, f := range defaults().fields() {
+		flags.String(f.flag(), "", fmt.Sprintf("%s (env %s)", f.usage, f.env))
+	}
+}
+
+// Load builds the configuration from, in increasing priority, the defaults,
+// the profile of the environment, the configuration file and its profile
+// section, environment variables and the flags that were set on the command
+// line, and validates it. flags may be nil. Values that can't be read or
+// parsed and the problems found by Validate are reported together as a
+// *ValidationError, the configuration is returned with it so that it can be
+// printed.
+func Load(flags *pflag.FlagSet) (*Config, error) {
+	config := defaults()
+	// report adds err, or every error joined in it, to the problems
+	var problems []string
+	report := func(prefix string, err error) {
+		if err == nil {
+			return
+		}
+		errs := []error{err}
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			errs = joined.Unwrap()
+		}
+		for _, err := range errs {
+			problems = append(problems, prefix+err.Error())
+		}
+	}
+
+	path := os.Getenv(EnvConfig)
+	if flags != nil {
+		if p, err := flags.GetString(FlagConfig); err == nil && p != "" {
+			path = p
+		}
+	}
+
+	var file configFile
+	if path != "" {
+		var err error
+		file, err = readFile(path)
+		report("", err)
+	}
+
+	environment := environment(flags, file)
+	report(fmt.Sprintf("profile %s: ", environment), config.applyValues(profiles[environment], SourceProfile))
+	report(fmt.Sprintf("config file %s: ", path), config.applyValues(file.values, SourceFile))
+	report(fmt.Sprintf("config file %s: profile %s: ", path, environment), config.applyValues(file.profiles[environment], SourceProfile))
+
+	report("", config.applyEnv())
+
+	if flags != nil {
+		report("", config.applyFlags(flags))
+	}
+
+	var errValidation *ValidationError
+	if err := config.Validate(); errors.As(err, &errValidation) {
+		problems = append(problems, errValidation.Problems...)
+	}
+	if len(problems) != 0 {
+		return config, &ValidationError{Problems: problems}
+	}
+
+	return config, nil
+}
+
+// Source returns where the value of key was taken from
+func (c *Config) Source(key string) string {
+	return c.sources[key]
+}
+
+// configFile holds the flattened keys of a configuration file and of each
+// environment under its "profiles" section
+type configFile struct {
+	values   map[string]string
+	profiles map[string]map[string]string
+}
+
+func readFile(path string) (configFile, error) {
+	var file configFile
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return file, fmt.Errorf("read config file: %w", err)
+	}
+
+	values := make(map[string]interface{})
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &values)
+	case ".toml":
+		err = toml.Unmarshal(data, &values)
+	default:
+		return file, fmt.Errorf("config file %s: unsupported extension %q, expected .yaml, .yml or .toml", path, ext)
+	}
+	if err != nil {
+		return file, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+
+	file.profiles = make(map[string]map[string]string)
+	if raw, ok := values[profilesKey]; ok {
+		delete(values, profilesKey)
+		sections, ok := raw.(map[string]interface{})
+		if !ok {
+			return file, fmt.Errorf("config file %s: %s must be a map of environments", path, profilesKey)
+		}
+		for environment, section := range sections {
+			flat := make(map[string]string)
+			flatten("", section, flat)
+			file.profiles[environment] = flat
+		}
+	}
+
+	file.values = make(map[string]string)
+	flatten("", values, file.values)
+
+	return file, nil
+}
+
+// environment resolves the environment before the other layers are applied
+// so that the matching profile can be laid under them
+func environment(flags *pflag.FlagSet, file configFile) string {
+	if flags != nil {
+		if flag := flags.Lookup("environment"); flag != nil && flag.Changed {
+			return flag.Value.String()
+		}
+	}
+	if environment, ok := os.LookupEnv("ENVIRONMENT"); ok {
+		return environment
+	}
+	if environment, ok := file.values["environment"]; ok {
+		return environment
+	}
+	return defaults().Environment
+}
+
+// applyValues sets the keys of a flattened file or profile, unknown keys and
+// invalid values are reported together. Entries of map keys are merged into the current map while
+// environment variables and flags replace it as a whole.
+func (c *Config) applyValues(values map[string]string, source string) error {
+	fields := make(map[string]field)
+	var maps []field
+	for _, f := range c.fields() {
+		fields[f.key] = f
+		if _, ok := f.value.(*map[string][]string); ok {
+			maps = append(maps, f)
+		}
+	}
+
+	var (
+		errs    []error
+		unknown []string
+	)
+	// keys are applied in order for the errors to be reported in order
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		raw := values[key]
+		if f, ok := fields[key]; ok {
+			if err := f.set(raw); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			c.sources[key] = source
+			continue
+		}
+
+		found := false
+		for _, f := range maps {
+			if name, ok := strings.CutPrefix(key, f.key+"."); ok {
+				if err := f.setEntry(name, raw); err != nil {
+					errs = append(errs, err)
+				} else {
+					c.sources[f.key] = source
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) != 0 {
+		errs = append(errs, fmt.Errorf("unknown keys %s", strings.Join(unknown, ", ")))
+	}
+
+	return errors.Join(errs...)
+}
+
+// applyEnv sets every key whose environment variable is defined, or read from
+// the file named by the variable with a _FILE suffix as done for docker and
+// kubernetes secrets. Keys with invalid values keep their previous value and
+// are reported together.
+func (c *Config) applyEnv() error {
+	var errs []error
+	for _, f := range c.fields() {
+		raw, ok := os.LookupEnv(f.env)
+		path, fromFile := os.LookupEnv(f.env + fileSuffix)
+		source := SourceEnv
+
+		switch {
+		case ok && fromFile:
+			errs = append(errs, fmt.Errorf("env %s and %s%s are both set", f.env, f.env, fileSuffix))
+			continue
+		case fromFile:
+			data, err := os.ReadFile(path)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("env %s%s: %w", f.env, fileSuffix, err))
+				continue
+			}
+			raw, source = strings.TrimRight(string(data), "\r\n"), SourceSecretFile
+		case !ok:
+			continue
+		}
+
+		if err := f.set(raw); err != nil {
+			errs = append(errs, fmt.Errorf("env %s: %w", f.env, err))
+			continue
+		}
+		c.sources[f.key] = source
+	}
+	return errors.Join(errs...)
+}
+
+// applyFlags sets every key whose flag was set, flags with invalid values
+// are reported together
+func (c *Config) applyFlags(flags *pflag.FlagSet) error {
+	var errs []error
+	for _, f := range c.fields() {
+		flag := flags.Lookup(f.flag())
+		if flag == nil || !flag.Changed {
+			continue
+		}
+		if err := f.set(flag.Value.String()); err != nil {
+			errs = append(errs, fmt.Errorf("flag --%s: %w", f.flag(), err))
+			continue
+		}
+		c.sources[f.key] = SourceFlag
+	}
+	return errors.Join(errs...)
+}
+
+// flatten turns nested maps into dotted keys, lists are joined with commas
+func flatten(prefix string, value interface{}, out map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			flatten(key, item, out)
+		}
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+		out[prefix] = strings.Join(items, ",")
+	case nil:
+		out[prefix] = ""
+	case string:
+		out[prefix] = v
+	case int:
+		out[prefix] = strconv.Itoa(v)
+	case int64:
+		out[prefix] = strconv.FormatInt(v, 10)
+	default:
+		out[prefix] = fmt.Sprint(v)
+	}
+}
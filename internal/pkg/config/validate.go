@@ -0,0 +1,238 @@
+package config
+
+import (
+	"fifth_exam/job_service/internal/pkg/app"
+	"fifth_exam/job_service/internal/pkg/auth"
+	"fifth_exam/job_service/internal/pkg/ratelimit"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const redacted = "******"
+
+var (
+	sslModes  = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+	logLevels = []string{"debug", "info", "warn", "error", "dpanic", "panic", "fatal"}
+	// logOutputs mirrors the outputs of logger.New
+	logOutputs = []string{"stdout", "stderr", "file"}
+	// deletePolicies mirrors usecase.ClientDeleteCascade and ClientDeleteBlock
+	deletePolicies = []string{"cascade", "block"}
+	// tracingExporters and tracingSamplers mirror the ones of otlp.InitOTLPProvider,
+	// config can't import otlp without a cycle
+	tracingExporters = []string{"otlp-grpc", "otlp-http", "stdout", "none"}
+	tracingSamplers  = []string{"always_on", "always_off", "ratio"}
+)
+
+// ValidationError lists every problem found in a configuration
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  " + strings.Join(e.Problems, "\n  ")
+}
+
+// Validate checks the configuration and reports all problems at once as a
+// *ValidationError
+func (c *Config) Validate() error {
+	var v validator
+
+	v.required("app", c.APP)
+	v.oneOf("environment", c.Environment, []string{app.EnvironmentDevelop, app.EnvironmentProduction})
+	v.oneOf("log_level", c.LogLevel, logLevels)
+	if len(c.Log.Outputs) == 0 {
+		v.add("log.outputs", "is required")
+	}
+	for _, output := range c.Log.Outputs {
+		v.oneOf("log.outputs", output, logOutputs)
+	}
+	if c.Log.MaxSizeMB < 0 {
+		v.add("log.max_size_mb", "must not be negative")
+	}
+	if c.Log.MaxBackups < 0 {
+		v.add("log.max_backups", "must not be negative")
+	}
+	v.notNegative("log.max_age", c.Log.MaxAge)
+	v.notNegative("log.rotate_interval", c.Log.RotateInterval)
+	v.address("rpc_port", c.RPCPort)
+	v.positive("shutdown_timeout", c.ShutdownTimeout)
+	v.positive("context.timeout", c.Context.Timeout)
+	v.positive("health.check_interval", c.Health.CheckInterval)
+	v.notNegative("health.drain_delay", c.Health.DrainDelay)
+
+	v.required("db.host", c.DB.Host)
+	v.port("db.port", c.DB.Port)
+	v.required("db.name", c.DB.Name)
+	v.required("db.user", c.DB.User)
+	v.oneOf("db.sslmode", c.DB.SslMode, sslModes)
+
+	if c.Auth.Enabled && c.Auth.HMACSecret == "" && c.Auth.RSAPublicKey == "" {
+		v.add("auth", "hmac_secret or rsa_public_key is required when auth is enabled")
+	}
+	if c.Environment == app.EnvironmentProduction && !c.Auth.Enabled {
+		v.add("auth.enabled", "must be true in production")
+	}
+	v.required("auth.roles_claim", c.Auth.RolesClaim)
+	v.notNegative("auth.leeway", c.Auth.Leeway)
+
+	if len(c.RBAC.Roles) == 0 {
+		v.add("rbac.roles", "at least one role is required")
+	}
+	for method := range c.RBAC.Methods {
+		if !auth.ValidMethod(method) {
+			v.add("rbac.methods", fmt.Sprintf("%q is not a full method name such as /job.JobService/Delete", method))
+		}
+	}
+
+	if _, err := ratelimit.ParseQuota(c.RateLimit.Default); err != nil {
+		v.add("rate_limit.default", err.Error())
+	}
+	for method, quota := range c.RateLimit.Methods {
+		if !auth.ValidMethod(method) {
+			v.add("rate_limit.methods", fmt.Sprintf("%q is not a full method name such as /job.JobService/GetList", method))
+		} else if _, err := ratelimit.ParseQuota(quota); err != nil {
+			v.add("rate_limit.methods", fmt.Sprintf("%s: %s", method, err))
+		}
+	}
+	v.positive("rate_limit.idle_timeout", c.RateLimit.IdleTimeout)
+	if c.List.MaxLimit <= 0 {
+		v.add("list.max_limit", "must be positive")
+	}
+
+	v.oneOf("client.delete_policy", c.Client.DeletePolicy, deletePolicies)
+
+	v.oneOf("tracing.exporter", c.Tracing.Exporter, tracingExporters)
+	v.oneOf("tracing.sampler", c.Tracing.Sampler, tracingSamplers)
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		v.add("tracing.sample_ratio", "must be between 0 and 1")
+	}
+	if strings.HasPrefix(c.Tracing.Exporter, "otlp") {
+		v.required("otlp_collector.host", c.OTLPCollector.Host)
+		v.address("otlp_collector.port", c.OTLPCollector.Port)
+	}
+	if c.OTLPCollector.Insecure && c.OTLPCollector.CAFile != "" {
+		v.add("otlp_collector.ca_file", "is only used when insecure is false")
+	}
+
+	v.address("metrics.port", c.Metrics.Port)
+	v.address("metrics.consumer_port", c.Metrics.ConsumerPort)
+	if c.Gateway.Enabled {
+		v.address("gateway.port", c.Gateway.Port)
+	}
+	if c.Admin.Enabled {
+		v.address("admin.port", c.Admin.Port)
+		v.address("admin.consumer_port", c.Admin.ConsumerPort)
+		if c.Environment == app.EnvironmentProduction && c.Admin.Token == "" {
+			v.add("admin.token", "is required in production")
+		}
+	}
+
+	if len(c.Kafka.Address) == 0 {
+		v.add("kafka.address", "at least one broker is required")
+	}
+	for _, broker := range c.Kafka.Address {
+		if _, port, err := net.SplitHostPort(broker); err != nil {
+			v.add("kafka.address", fmt.Sprintf("broker %q must be host:port", broker))
+		} else if !validPort(port) {
+			v.add("kafka.address", fmt.Sprintf("broker %q has an invalid port", broker))
+		}
+	}
+	v.required("kafka.consumer_group", c.Kafka.ConsumerGroup)
+	v.required("kafka.topic.job_topic", c.Kafka.Topic.JobTopic)
+
+	if len(v.problems) != 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+	return nil
+}
+
+// Entry is one configuration key with its effective value
+type Entry struct {
+	Key    string
+	Env    string
+	Value  string
+	Source string
+	Secret bool
+}
+
+// Entries returns every configuration key in declaration order, values of
+// secrets are redacted
+func (c *Config) Entries() []Entry {
+	fields := c.fields()
+	entries := make([]Entry, 0, len(fields))
+	for _, f := range fields {
+		value := f.String()
+		if f.secret && value != "" {
+			value = redacted
+		}
+		entries = append(entries, Entry{
+			Key:    f.key,
+			Env:    f.env,
+			Value:  value,
+			Source: c.Source(f.key),
+			Secret: f.secret,
+		})
+	}
+	return entries
+}
+
+type validator struct {
+	problems []string
+}
+
+func (v *validator) add(key, problem string) {
+	v.problems = append(v.problems, key+": "+problem)
+}
+
+func (v *validator) required(key, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(key, "is required")
+	}
+}
+
+func (v *validator) oneOf(key, value string, allowed []string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.add(key, fmt.Sprintf("%q is not one of %s", value, strings.Join(allowed, ", ")))
+}
+
+func (v *validator) positive(key string, d time.Duration) {
+	if d <= 0 {
+		v.add(key, "must be a positive duration")
+	}
+}
+
+func (v *validator) notNegative(key string, d time.Duration) {
+	if d < 0 {
+		v.add(key, "must not be negative")
+	}
+}
+
+func (v *validator) port(key, value string) {
+	if !validPort(value) {
+		v.add(key, fmt.Sprintf("%q is not a valid port", value))
+	}
+}
+
+// address accepts listen addresses such as ":9090" or "0.0.0.0:9090"
+func (v *validator) address(key, value string) {
+	_, port, err := net.SplitHostPort(value)
+	if err != nil {
+		v.add(key, fmt.Sprintf("%q must be [host]:port", value))
+		return
+	}
+	if !validPort(port) {
+		v.add(key, fmt.Sprintf("%q has an invalid port", value))
+	}
+}
+
+func validPort(value string) bool {
+	port, err := strconv.Atoi(value)
+	return err == nil && port > 0 && port <= 65535
+}
@@ -0,0 +1,57 @@
+package logger
+
+import (
+	"context"
+	"fifth_exam/job_service/internal/pkg/auth"
+	"fifth_exam/job_service/internal/pkg/requestid"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type key struct{}
+
+// WithContext returns a copy of ctx carrying logger, the logger of an RPC or
+// of a consumed message is set once and picked up by every layer handling it
+func WithContext(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, key{}, logger)
+}
+
+// FromContext returns the logger of ctx with the fields of Fields, the
+// global zap logger is used when ctx carries none
+func FromContext(ctx context.Context) *zap.Logger {
+	logger, ok := ctx.Value(key{}).(*zap.Logger)
+	if !ok {
+		logger = zap.L()
+	}
+	return With(ctx, logger)
+}
+
+// With returns logger with the fields of Fields
+func With(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	fields := Fields(ctx)
+	if len(fields) == 0 {
+		return logger
+	}
+	return logger.With(fields...)
+}
+
+// Fields returns the trace and span ids, the request id and the subject of
+// the caller found in ctx, so that log lines can be found from a trace and
+// the other way around
+func Fields(ctx context.Context) []zap.Field {
+	var fields []zap.Field
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		fields = append(fields,
+			zap.String("trace_id", spanContext.TraceID().String()),
+			zap.String("span_id", spanContext.SpanID().String()),
+		)
+	}
+	if id := requestid.FromContext(ctx); id != "" {
+		fields = append(fields, zap.String("request_id", id))
+	}
+	if identity, ok := auth.FromContext(ctx); ok {
+		fields = append(fields, zap.String("subject", identity.Subject))
+	}
+	return fields
+}
@@ -0,0 +1,63 @@
+package logger
+
+import (
+	"context"
+	"fifth_exam/job_service/internal/pkg/auth"
+	"fifth_exam/job_service/internal/pkg/requestid"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type ContextTestSuite struct {
+	suite.Suite
+	Logs   *observer.ObservedLogs
+	Logger *zap.Logger
+}
+
+func (s *ContextTestSuite) SetupTest() {
+	core, logs := observer.New(zapcore.DebugLevel)
+	s.Logs = logs
+	s.Logger = zap.New(core)
+}
+
+func (s *ContextTestSuite) TestFromContext() {
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+	ctx = requestid.WithID(ctx, "req-1")
+	ctx = auth.WithIdentity(ctx, &auth.Identity{Subject: "client-1"})
+	ctx = WithContext(ctx, s.Logger.With(zap.String("grpc_method", "/job.JobService/Get")))
+
+	FromContext(ctx).Info("job fetched")
+
+	entries := s.Logs.TakeAll()
+	s.Suite.Require().Len(entries, 1)
+	s.Suite.Equal(map[string]interface{}{
+		"grpc_method": "/job.JobService/Get",
+		"trace_id":    spanContext.TraceID().String(),
+		"span_id":     spanContext.SpanID().String(),
+		"request_id":  "req-1",
+		"subject":     "client-1",
+	}, entries[0].ContextMap())
+}
+
+func (s *ContextTestSuite) TestWithoutContext() {
+	s.Suite.Empty(Fields(context.Background()))
+	s.Suite.Same(s.Logger, With(context.Background(), s.Logger))
+
+	defer zap.ReplaceGlobals(s.Logger)()
+	FromContext(context.Background()).Warn("no request")
+	s.Suite.Equal(1, s.Logs.FilterMessage("no request").Len())
+}
+
+func TestContextTestSuite(t *testing.T) {
+	suite.Run(t, new(ContextTestSuite))
+}
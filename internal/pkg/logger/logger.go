@@ -2,53 +2,76 @@ package logger
 
 import (
 	"fifth_exam/job_service/internal/pkg/app"
-	"path/filepath"
+	"fmt"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
-func productionConfig(file string) zap.Config {
+const (
+	OutputStdout = "stdout"
+	OutputStderr = "stderr"
+	// OutputFile writes to Config.File with its rotation
+	OutputFile = "file"
+)
+
+type Config struct {
+	// Level is shared with whatever changes it at runtime
+	Level       zap.AtomicLevel
+	Environment string
+	Outputs     []string
+	File        string
+	Rotation    Rotation
+}
+
+func productionConfig() zap.Config {
 	configZap := zap.NewProductionConfig()
-	configZap.OutputPaths = []string{"stdout", file}
 	configZap.DisableStacktrace = true
 	return configZap
 }
 
-func developmentConfig(file string) zap.Config {
+func developmentConfig() zap.Config {
 	configZap := zap.NewDevelopmentConfig()
-	configZap.OutputPaths = []string{"stdout", file}
 	configZap.ErrorOutputPaths = []string{"stderr"}
 	return configZap
 }
 
-func New(level, environment string, file_name string) (*zap.Logger, error) {
-	file := filepath.Join("./" + file_name)
+// NewLevel returns an atomic level set to level, debug when it is unknown
+func NewLevel(level string) zap.AtomicLevel {
+	l, err := zapcore.ParseLevel(level)
+	if err != nil {
+		l = zapcore.DebugLevel
+	}
+	return zap.NewAtomicLevelAt(l)
+}
 
-	configZap := productionConfig(file)
+func New(config Config) (*zap.Logger, error) {
+	configZap := productionConfig()
 
-	if environment == app.EnvironmentDevelop {
-		configZap = developmentConfig(file)
+	if config.Environment == app.EnvironmentDevelop {
+		configZap = developmentConfig()
 	}
 
-	switch level {
-	case "debug":
-		configZap.Level = zap.NewAtomicLevelAt(zap.DebugLevel)
-	case "info":
-		configZap.Level = zap.NewAtomicLevelAt(zap.InfoLevel)
-	case "warn":
-		configZap.Level = zap.NewAtomicLevelAt(zap.WarnLevel)
-	case "error":
-		configZap.Level = zap.NewAtomicLevelAt(zap.ErrorLevel)
-	case "dpanic":
-		configZap.Level = zap.NewAtomicLevelAt(zap.DPanicLevel)
-	case "panic":
-		configZap.Level = zap.NewAtomicLevelAt(zap.PanicLevel)
-	case "fatal":
-		configZap.Level = zap.NewAtomicLevelAt(zap.FatalLevel)
-	default:
-		configZap.Level = zap.NewAtomicLevelAt(zap.DebugLevel)
+	configZap.OutputPaths = nil
+	for _, output := range config.Outputs {
+		switch output {
+		case OutputStdout, OutputStderr:
+			configZap.OutputPaths = append(configZap.OutputPaths, output)
+		case OutputFile:
+			if err := registerRotateSink(); err != nil {
+				return nil, err
+			}
+			path, err := config.Rotation.url(config.File)
+			if err != nil {
+				return nil, err
+			}
+			configZap.OutputPaths = append(configZap.OutputPaths, path)
+		default:
+			return nil, fmt.Errorf("unknown log output %q", output)
+		}
 	}
+
+	configZap.Level = config.Level
 	return configZap.Build()
 }
 
@@ -0,0 +1,226 @@
+package logger
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// rotateScheme is the zap sink scheme of rotated log files, the rotation is
+// given by the query of the URL, see Rotation.url
+const rotateScheme = "rotate"
+
+// backupTimeFormat sorts backups by the time they were rotated at
+const backupTimeFormat = "20060102T150405.000"
+
+var registerRotate sync.Once
+
+// Rotation bounds the log file: it is rotated once it reaches MaxSize bytes
+// or has been written to for Interval, and the rotated files beyond
+// MaxBackups or older than MaxAge are removed. Zero values disable the
+// corresponding limit.
+type Rotation struct {
+	MaxSize    int64
+	MaxBackups int64
+	MaxAge     time.Duration
+	Interval   time.Duration
+}
+
+// url returns the zap output path writing to path with the rotation r
+func (r Rotation) url(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("log file %s: %w", path, err)
+	}
+
+	query := url.Values{}
+	query.Set("max_size", strconv.FormatInt(r.MaxSize, 10))
+	query.Set("max_backups", strconv.FormatInt(r.MaxBackups, 10))
+	query.Set("max_age", r.MaxAge.String())
+	query.Set("interval", r.Interval.String())
+
+	u := url.URL{Scheme: rotateScheme, Path: filepath.ToSlash(abs), RawQuery: query.Encode()}
+	return u.String(), nil
+}
+
+func registerRotateSink() error {
+	var err error
+	registerRotate.Do(func() {
+		err = zap.RegisterSink(rotateScheme, func(u *url.URL) (zap.Sink, error) {
+			return newRotatingFile(u)
+		})
+	})
+	return err
+}
+
+// rotatingFile is a zap sink renaming the log file to
+// <name>-<time>.<ext> when it is rotated
+type rotatingFile struct {
+	mu       sync.Mutex
+	path     string
+	rotation Rotation
+	file     *os.File
+	size     int64
+	opened   time.Time
+	now      func() time.Time
+}
+
+func newRotatingFile(u *url.URL) (*rotatingFile, error) {
+	var (
+		query = u.Query()
+		r     Rotation
+		err   error
+	)
+	if r.MaxSize, err = strconv.ParseInt(query.Get("max_size"), 10, 64); err != nil {
+		return nil, fmt.Errorf("log rotation max_size: %w", err)
+	}
+	if r.MaxBackups, err = strconv.ParseInt(query.Get("max_backups"), 10, 64); err != nil {
+		return nil, fmt.Errorf("log rotation max_backups: %w", err)
+	}
+	if r.MaxAge, err = time.ParseDuration(query.Get("max_age")); err != nil {
+		return nil, fmt.Errorf("log rotation max_age: %w", err)
+	}
+	if r.Interval, err = time.ParseDuration(query.Get("interval")); err != nil {
+		return nil, fmt.Errorf("log rotation interval: %w", err)
+	}
+
+	f := &rotatingFile{path: filepath.FromSlash(u.Path), rotation: r, now: time.Now}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+	if f.due(int64(len(p))) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *rotatingFile) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	return f.file.Sync()
+}
+
+func (f *rotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// due reports whether writing n more bytes needs the file to be rotated
+// first, a single entry larger than MaxSize is still written
+func (f *rotatingFile) due(n int64) bool {
+	if f.rotation.MaxSize > 0 && f.size > 0 && f.size+n > f.rotation.MaxSize {
+		return true
+	}
+	return f.rotation.Interval > 0 && f.now().Sub(f.opened) >= f.rotation.Interval
+}
+
+func (f *rotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return fmt.Errorf("log directory: %w", err)
+	}
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("stat log file: %w", err)
+	}
+
+	f.file, f.size, f.opened = file, info.Size(), f.now()
+	return nil
+}
+
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("close log file: %w", err)
+	}
+	f.file = nil
+
+	if err := os.Rename(f.path, f.backupName(f.now())); err != nil {
+		return fmt.Errorf("rotate log file: %w", err)
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	// retention failures must not lose log entries
+	f.removeBackups()
+	return nil
+}
+
+func (f *rotatingFile) backupName(t time.Time) string {
+	ext := filepath.Ext(f.path)
+	return strings.TrimSuffix(f.path, ext) + "-" + t.UTC().Format(backupTimeFormat) + ext
+}
+
+// backups returns the rotated files of the log file, oldest first
+func (f *rotatingFile) backups() []string {
+	ext := filepath.Ext(f.path)
+	prefix := strings.TrimSuffix(f.path, ext) + "-"
+
+	matches, _ := filepath.Glob(prefix + "*" + ext)
+	var backups []string
+	for _, match := range matches {
+		stamp := strings.TrimSuffix(strings.TrimPrefix(match, prefix), ext)
+		if _, err := time.Parse(backupTimeFormat, stamp); err == nil {
+			backups = append(backups, match)
+		}
+	}
+	sort.Strings(backups)
+	return backups
+}
+
+func (f *rotatingFile) removeBackups() {
+	backups := f.backups()
+	ext := filepath.Ext(f.path)
+	prefix := strings.TrimSuffix(f.path, ext) + "-"
+
+	for i, backup := range backups {
+		expired := f.rotation.MaxBackups > 0 && int64(len(backups)-i) > f.rotation.MaxBackups
+		if !expired && f.rotation.MaxAge > 0 {
+			rotated, _ := time.Parse(backupTimeFormat, strings.TrimSuffix(strings.TrimPrefix(backup, prefix), ext))
+			expired = f.now().Sub(rotated) > f.rotation.MaxAge
+		}
+		if expired {
+			os.Remove(backup)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type RotateTestSuite struct {
+	suite.Suite
+	Path string
+	Now  time.Time
+}
+
+func (s *RotateTestSuite) SetupTest() {
+	s.Path = filepath.Join(s.T().TempDir(), "logs", "job_service.log")
+	s.Now = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+}
+
+func (s *RotateTestSuite) open(rotation Rotation) *rotatingFile {
+	f := &rotatingFile{path: s.Path, rotation: rotation, now: func() time.Time { return s.Now }}
+	s.Suite.Require().NoError(f.open())
+	s.T().Cleanup(func() { f.Close() })
+	return f
+}
+
+func (s *RotateTestSuite) write(f *rotatingFile, line string) {
+	_, err := f.Write([]byte(line + "\n"))
+	s.Suite.Require().NoError(err)
+}
+
+func (s *RotateTestSuite) TestSize() {
+	f := s.open(Rotation{MaxSize: 10})
+
+	s.write(f, "first")
+	s.write(f, "second")
+	s.Now = s.Now.Add(time.Second)
+	s.write(f, "third")
+
+	backups := f.backups()
+	s.Suite.Require().Len(backups, 2)
+	data, err := os.ReadFile(backups[0])
+	s.Suite.NoError(err)
+	s.Suite.Equal("first\n", string(data))
+
+	data, err = os.ReadFile(s.Path)
+	s.Suite.NoError(err)
+	s.Suite.Equal("third\n", string(data))
+}
+
+func (s *RotateTestSuite) TestIntervalAndRetention() {
+	f := s.open(Rotation{Interval: time.Hour, MaxBackups: 2, MaxAge: 3 * time.Hour})
+
+	for i := 0; i < 4; i++ {
+		s.write(f, "entry")
+		s.Now = s.Now.Add(time.Hour)
+	}
+	s.write(f, "entry")
+	s.Suite.Len(f.backups(), 2)
+
+	// backups older than MaxAge go even below MaxBackups
+	s.Now = s.Now.Add(5 * time.Hour)
+	s.write(f, "entry")
+	s.Suite.Len(f.backups(), 1)
+}
+
+func (s *RotateTestSuite) TestLogger() {
+	level := NewLevel("info")
+	logger, err := New(Config{
+		Level:       level,
+		Environment: "production",
+		Outputs:     []string{OutputFile},
+		File:        s.Path,
+		Rotation:    Rotation{MaxSize: 1 << 20},
+	})
+	s.Suite.Require().NoError(err)
+
+	logger.Debug("hidden")
+	level.SetLevel(zapcore.DebugLevel)
+	logger.Debug("shown", zap.String("job_id", "1"))
+	s.Suite.NoError(logger.Sync())
+
+	data, err := os.ReadFile(s.Path)
+	s.Suite.NoError(err)
+	s.Suite.NotContains(string(data), "hidden")
+	s.Suite.Contains(string(data), `"msg":"shown"`)
+
+	_, err = New(Config{Level: level, Outputs: []string{"syslog"}})
+	s.Suite.ErrorContains(err, "syslog")
+}
+
+func TestRotateTestSuite(t *testing.T) {
+	suite.Run(t, new(RotateTestSuite))
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package logger
+
+import "go.uber.org/zap"
+
+// HandleLevelSignal does nothing where SIGUSR1 does not exist
+func HandleLevelSignal(level zap.AtomicLevel, logger *zap.Logger) func() {
+	return func() {}
+}
@@ -0,0 +1,43 @@
+//go:build unix
+
+package logger
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// HandleLevelSignal switches level to debug on SIGUSR1 and back to the level
+// it had when called on the next one, so that a running process can be
+// debugged without a restart. The returned function stops the handler.
+func HandleLevelSignal(level zap.AtomicLevel, logger *zap.Logger) func() {
+	initial := level.Level()
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, syscall.SIGUSR1)
+
+	go func() {
+		for {
+			select {
+			case <-signals:
+				next := zapcore.DebugLevel
+				if level.Level() == zapcore.DebugLevel {
+					next = initial
+				}
+				level.SetLevel(next)
+				logger.Warn("log level changed by signal", zap.Stringer("level", next))
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
@@ -2,24 +2,44 @@ package otlp
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fifth_exam/job_service/internal/pkg/buildinfo"
 	"fifth_exam/job_service/internal/pkg/config"
 	"fmt"
+	"os"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
-	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"google.golang.org/grpc/credentials"
 )
 
-// Initializes an OTLP exporter, and configures the corresponding trace
-func InitOTLPProvider(config *config.Config) (func() error, error) {
-	var (
-		ctx           = context.Background()
-		otelAgentAddr = fmt.Sprintf("%s%s", config.OTLPCollector.Host, config.OTLPCollector.Port)
-	)
+const (
+	ExporterOTLPGRPC = "otlp-grpc"
+	ExporterOTLPHTTP = "otlp-http"
+	ExporterStdout   = "stdout"
+	ExporterNone     = "none"
+
+	SamplerAlwaysOn  = "always_on"
+	SamplerAlwaysOff = "always_off"
+	SamplerRatio     = "ratio"
+)
+
+// Initializes the exporter and sampler of the tracing configuration, and
+// configures the corresponding trace provider. The returned function flushes
+// pending spans and shuts the exporter down, it gives up when ctx is done.
+//
+// The OTLP exporters connect lazily, a collector that is down loses spans
+// but does not keep the service from starting.
+func InitOTLPProvider(config *config.Config) (func(ctx context.Context) error, error) {
+	ctx := context.Background()
 
 	res, err := resource.New(ctx,
 		resource.WithFromEnv(),
@@ -29,34 +49,40 @@ func InitOTLPProvider(config *config.Config) (func() error, error) {
 		resource.WithAttributes(
 			// the service name used to display traces in backends
 			semconv.ServiceNameKey.String(config.APP),
-			semconv.ServiceVersionKey.String("1.0.0"),
+			semconv.ServiceVersionKey.String(buildinfo.Get().Version),
 			semconv.DeploymentEnvironmentKey.String(config.Environment),
 		),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("otlp collector failed to create resource: %w", err)
 	}
-	traceClient := otlptracegrpc.NewClient(
-		otlptracegrpc.WithInsecure(),
-		otlptracegrpc.WithEndpoint(otelAgentAddr),
-	)
 
-	traceExporter, err := otlptrace.New(ctx, traceClient)
+	sampler, err := newSampler(config)
 	if err != nil {
-		return nil, fmt.Errorf("otlp collector failed to create trace exporter: %w", err)
+		return nil, err
 	}
-	// Register the trace exporter with a TracerProvider, using a batch
-	// span processor to aggregate spans before export.
-	bsp := sdktrace.NewBatchSpanProcessor(traceExporter)
-	tracerProvider := sdktrace.NewTracerProvider(
-		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(sampler),
 		sdktrace.WithResource(res),
-		sdktrace.WithSpanProcessor(bsp),
-	)
-	// set global propagator to tracecontext (the default is no-op).
-	otel.SetTextMapPropagator(propagation.TraceContext{})
+	}
+
+	traceExporter, err := newExporter(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	// Register the trace exporter with a TracerProvider, using a batch
+	// span processor to aggregate spans before export. Without an exporter
+	// spans are still recorded so that logs keep their trace ids.
+	if traceExporter != nil {
+		options = append(options, sdktrace.WithBatcher(traceExporter))
+	}
+	tracerProvider := sdktrace.NewTracerProvider(options...)
+
+	// set global propagator to tracecontext and baggage (the default is no-op),
+	// the gRPC server reads the parent span of incoming RPCs with it
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
 	otel.SetTracerProvider(tracerProvider)
-	return func() error {
+	return func(ctx context.Context) error {
 		// Shutdown will flush any remaining spans and shut down the exporter.
 		if err := tracerProvider.Shutdown(ctx); err != nil {
 			return fmt.Errorf("otlp collector failed to shutdown TracerProvider: %w", err)
@@ -64,3 +90,94 @@ func InitOTLPProvider(config *config.Config) (func() error, error) {
 		return nil
 	}, nil
 }
+
+// newSampler returns the sampler of the tracing configuration, with parent
+// based sampling the decision of a sampled caller is followed
+func newSampler(config *config.Config) (sdktrace.Sampler, error) {
+	var sampler sdktrace.Sampler
+	switch config.Tracing.Sampler {
+	case SamplerAlwaysOn:
+		sampler = sdktrace.AlwaysSample()
+	case SamplerAlwaysOff:
+		sampler = sdktrace.NeverSample()
+	case SamplerRatio:
+		sampler = sdktrace.TraceIDRatioBased(config.Tracing.SampleRatio)
+	default:
+		return nil, fmt.Errorf("unknown trace sampler %q", config.Tracing.Sampler)
+	}
+
+	if config.Tracing.ParentBased {
+		sampler = sdktrace.ParentBased(sampler)
+	}
+	return sampler, nil
+}
+
+// newExporter returns the span exporter of the tracing configuration, nil
+// when spans are not exported
+func newExporter(ctx context.Context, config *config.Config) (sdktrace.SpanExporter, error) {
+	endpoint := fmt.Sprintf("%s%s", config.OTLPCollector.Host, config.OTLPCollector.Port)
+
+	switch config.Tracing.Exporter {
+	case ExporterOTLPGRPC:
+		options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(endpoint)}
+		if config.OTLPCollector.Insecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		} else {
+			tlsConfig, err := newTLSConfig(config.OTLPCollector.CAFile)
+			if err != nil {
+				return nil, err
+			}
+			options = append(options, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
+		}
+		return newOTLPExporter(ctx, otlptracegrpc.NewClient(options...))
+	case ExporterOTLPHTTP:
+		options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(endpoint)}
+		if config.OTLPCollector.Insecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		} else {
+			tlsConfig, err := newTLSConfig(config.OTLPCollector.CAFile)
+			if err != nil {
+				return nil, err
+			}
+			options = append(options, otlptracehttp.WithTLSClientConfig(tlsConfig))
+		}
+		return newOTLPExporter(ctx, otlptracehttp.NewClient(options...))
+	case ExporterStdout:
+		traceExporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stdout trace exporter: %w", err)
+		}
+		return traceExporter, nil
+	case ExporterNone:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unknown trace exporter %q", config.Tracing.Exporter)
+}
+
+func newOTLPExporter(ctx context.Context, client otlptrace.Client) (sdktrace.SpanExporter, error) {
+	traceExporter, err := otlptrace.New(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("otlp collector failed to create trace exporter: %w", err)
+	}
+	return traceExporter, nil
+}
+
+// newTLSConfig verifies the collector with the CA bundle of caFile, or with
+// the system roots when it is empty
+func newTLSConfig(caFile string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile == "" {
+		return tlsConfig, nil
+	}
+
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("otlp collector ca file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("otlp collector ca file %s: no certificates found", caFile)
+	}
+	tlsConfig.RootCAs = pool
+	return tlsConfig, nil
+}
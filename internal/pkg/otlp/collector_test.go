@@ -0,0 +1,82 @@
+package otlp
+
+import (
+	"context"
+	"fifth_exam/job_service/internal/pkg/config"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+type CollectorTestSuite struct {
+	suite.Suite
+	Config *config.Config
+}
+
+func (s *CollectorTestSuite) SetupTest() {
+	var err error
+	s.Config, err = config.Load(nil)
+	s.Suite.NoError(err)
+}
+
+func (s *CollectorTestSuite) TestSampler() {
+	s.Config.Tracing.Sampler = SamplerRatio
+	s.Config.Tracing.SampleRatio = 0.5
+	s.Config.Tracing.ParentBased = false
+	sampler, err := newSampler(s.Config)
+	s.Suite.NoError(err)
+	s.Suite.Equal(sdktrace.TraceIDRatioBased(0.5).Description(), sampler.Description())
+
+	s.Config.Tracing.Sampler = SamplerAlwaysOff
+	s.Config.Tracing.ParentBased = true
+	sampler, err = newSampler(s.Config)
+	s.Suite.NoError(err)
+	s.Suite.Equal(sdktrace.ParentBased(sdktrace.NeverSample()).Description(), sampler.Description())
+
+	s.Config.Tracing.Sampler = "sometimes"
+	_, err = newSampler(s.Config)
+	s.Suite.ErrorContains(err, "sometimes")
+}
+
+func (s *CollectorTestSuite) TestExporter() {
+	ctx := context.Background()
+
+	s.Config.Tracing.Exporter = ExporterNone
+	exporter, err := newExporter(ctx, s.Config)
+	s.Suite.NoError(err)
+	s.Suite.Nil(exporter)
+
+	// OTLP exporters connect lazily, no collector is listening here
+	for _, name := range []string{ExporterOTLPGRPC, ExporterOTLPHTTP, ExporterStdout} {
+		s.Config.Tracing.Exporter = name
+		exporter, err = newExporter(ctx, s.Config)
+		s.Suite.NoError(err, name)
+		s.Suite.NoError(exporter.Shutdown(ctx), name)
+	}
+
+	s.Config.Tracing.Exporter = ExporterOTLPGRPC
+	s.Config.OTLPCollector.Insecure = false
+	s.Config.OTLPCollector.CAFile = "/nonexistent/ca.pem"
+	_, err = newExporter(ctx, s.Config)
+	s.Suite.ErrorContains(err, "ca file")
+}
+
+func (s *CollectorTestSuite) TestWithoutExporter() {
+	s.Config.Tracing.Exporter = ExporterNone
+	shutdown, err := InitOTLPProvider(s.Config)
+	s.Suite.NoError(err)
+
+	// spans keep their ids for log correlation
+	_, span := otel.Tracer(TracerUsecase).Start(context.Background(), "JobUsecase.Get")
+	s.Suite.True(span.SpanContext().IsValid())
+	s.Suite.True(span.IsRecording())
+	span.End()
+
+	s.Suite.NoError(shutdown(context.Background()))
+}
+
+func TestCollectorTestSuite(t *testing.T) {
+	suite.Run(t, new(CollectorTestSuite))
+}
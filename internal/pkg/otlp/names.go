@@ -0,0 +1,57 @@
+package otlp
+
+import (
+	"context"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Spans form one hierarchy per request: the server span started by otelgrpc
+// and named after the RPC, e.g. "job.JobService/Create", the usecase span,
+// e.g. "JobUsecase.Create", and one client span per query, e.g.
+// "JobRepo.Create". Consumed messages start at a consumer span, e.g.
+// "job deliver". The tracers below name the instrumented layers.
+const (
+	TracerUsecase    = "fifth_exam/job_service/internal/usecase"
+	TracerRepository = "fifth_exam/job_service/internal/infrastructure/repository/postgresql"
+	TracerConsumer   = "fifth_exam/job_service/internal/infrastructure/kafka"
+)
+
+// Trace runs fn within a new span, the error returned by fn is recorded on
+// the span and sets its status
+func Trace[T any](ctx context.Context, tracer, spanName string, fn func(ctx context.Context) (T, error), options ...trace.SpanStartOption) (T, error) {
+	ctx, span := Start(ctx, tracer, spanName, options...)
+	defer span.End()
+
+	result, err := fn(ctx)
+	span.Error(err)
+	return result, err
+}
+
+// TraceError is Trace for functions that only return an error
+func TraceError(ctx context.Context, tracer, spanName string, fn func(ctx context.Context) error, options ...trace.SpanStartOption) error {
+	_, err := Trace(ctx, tracer, spanName, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	}, options...)
+	return err
+}
+
+// Query returns the options of the client span of a postgres query on
+// table, operation is the SQL verb such as SELECT
+func Query(operation, table string) []trace.SpanStartOption {
+	return []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperation(operation),
+			semconv.DBSQLTable(table),
+		),
+	}
+}
+
+// Statement records the SQL of a query on the span of ctx, values are bound
+// as arguments and never part of it
+func Statement(ctx context.Context, query string) {
+	trace.SpanFromContext(ctx).SetAttributes(semconv.DBStatement(query))
+}
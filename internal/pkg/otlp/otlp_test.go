@@ -0,0 +1,80 @@
+package otlp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type SpanTestSuite struct {
+	suite.Suite
+	Recorder *tracetest.SpanRecorder
+}
+
+func (s *SpanTestSuite) SetupTest() {
+	s.Recorder = tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.Recorder)))
+}
+
+func (s *SpanTestSuite) TestHierarchy() {
+	remote := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), remote)
+
+	errNoRows := errors.New("no rows")
+	_, err := Trace(ctx, TracerUsecase, "JobUsecase.Get", func(ctx context.Context) (string, error) {
+		return "", TraceError(ctx, TracerRepository, "JobRepo.Get", func(ctx context.Context) error {
+			Statement(ctx, "SELECT id FROM jobs WHERE id = $1")
+			return errNoRows
+		}, Query("SELECT", "jobs")...)
+	})
+	s.Suite.ErrorIs(err, errNoRows)
+
+	spans := s.Recorder.Ended()
+	s.Suite.Require().Len(spans, 2)
+	repo, uc := spans[0], spans[1]
+
+	s.Suite.Equal(remote.TraceID(), uc.SpanContext().TraceID())
+	s.Suite.Equal(remote.SpanID(), uc.Parent().SpanID())
+	s.Suite.Equal(uc.SpanContext().SpanID(), repo.Parent().SpanID())
+
+	s.Suite.Equal("JobRepo.Get", repo.Name())
+	s.Suite.Equal(trace.SpanKindClient, repo.SpanKind())
+	s.Suite.Equal(TracerRepository, repo.InstrumentationScope().Name)
+	s.Suite.Contains(repo.Attributes(), semconv.DBSystemPostgreSQL)
+	s.Suite.Contains(repo.Attributes(), semconv.DBSQLTable("jobs"))
+	s.Suite.Contains(repo.Attributes(), semconv.DBStatement("SELECT id FROM jobs WHERE id = $1"))
+	s.Suite.Equal(codes.Error, repo.Status().Code)
+	s.Suite.Equal(codes.Error, uc.Status().Code)
+	s.Suite.Len(repo.Events(), 1)
+	s.Suite.NotContains(uc.Attributes(), semconv.DBStatement("SELECT id FROM jobs WHERE id = $1"))
+}
+
+func (s *SpanTestSuite) TestSuccess() {
+	value, err := Trace(context.Background(), TracerUsecase, "JobUsecase.List", func(ctx context.Context) (int, error) {
+		return 3, nil
+	})
+	s.Suite.NoError(err)
+	s.Suite.Equal(3, value)
+
+	spans := s.Recorder.Ended()
+	s.Suite.Require().Len(spans, 1)
+	s.Suite.Equal(codes.Unset, spans[0].Status().Code)
+	s.Suite.Empty(spans[0].Events())
+}
+
+func TestSpanTestSuite(t *testing.T) {
+	suite.Run(t, new(SpanTestSuite))
+}
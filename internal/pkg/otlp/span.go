@@ -4,7 +4,6 @@ import (
 	"context"
 
 	otelpkg "go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -15,59 +14,27 @@ type Span interface {
 	Error(err error)
 }
 
-func Start(ctx context.Context, name, spanName string) (context.Context, Span) {
-	ctx, _span := otelpkg.Tracer(name).Start(ctx, spanName)
-	return ctx, &span{span: _span}
+func Start(ctx context.Context, name, spanName string, options ...trace.SpanStartOption) (context.Context, Span) {
+	ctx, _span := otelpkg.Tracer(name).Start(ctx, spanName, options...)
+	return ctx, &span{Span: _span}
 }
 
+// span adds error helpers to the span it wraps
 type span struct {
-	span trace.Span
-}
-
-func (s *span) End(options ...trace.SpanEndOption) {
-	s.span.End(options...)
+	trace.Span
 }
 
 func (s *span) EndError(err error, options ...trace.SpanEndOption) {
 	s.Error(err)
-	s.span.End(options...)
-}
-
-func (s *span) AddEvent(name string, options ...trace.EventOption) {
-	s.span.AddEvent(name, options...)
-}
-
-func (s *span) IsRecording() bool {
-	return s.span.IsRecording()
-}
-
-func (s *span) RecordError(err error, options ...trace.EventOption) {
-	s.span.RecordError(err, options...)
-}
-
-func (s *span) SpanContext() trace.SpanContext {
-	return s.span.SpanContext()
-}
-
-func (s *span) SetStatus(code codes.Code, description string) {
-	s.span.SetStatus(code, description)
-}
-
-func (s *span) SetName(name string) {
-	s.span.SetName(name)
-}
-
-func (s *span) SetAttributes(kv ...attribute.KeyValue) {
-	s.span.SetAttributes(kv...)
-}
-
-func (s *span) TracerProvider() trace.TracerProvider {
-	return s.span.TracerProvider()
+	s.Span.End(options...)
 }
 
+// Error records err as an event of the span and sets its status to Error,
+// nil errors are ignored
 func (s *span) Error(err error) {
 	if err != nil {
-		s.span.SetStatus(codes.Error, err.Error())
+		s.Span.RecordError(err)
+		s.Span.SetStatus(codes.Error, err.Error())
 	}
 }
 
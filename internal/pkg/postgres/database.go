@@ -6,7 +6,6 @@ import (
 	"fifth_exam/job_service/internal/entity"
 	"fifth_exam/job_service/internal/pkg/config"
 	"fmt"
-	"strings"
 
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
@@ -31,7 +30,7 @@ func New(config *config.Config) (*PostgresDB, error) {
 }
 
 func (p *PostgresDB) connectDB(config *config.Config) error {
-	pgxConfig, err := pgxpool.ParseConfig(p.configToString(config))
+	pgxConfig, err := pgxpool.ParseConfig(config.PostgresDSN())
 	if err != nil {
 		return fmt.Errorf("unable to parse db conifg: %s", err.Error())
 	}
@@ -46,35 +45,6 @@ func (p *PostgresDB) connectDB(config *config.Config) error {
 	return nil
 }
 
-func (p *PostgresDB) configToString(config *config.Config) string {
-	var conn []string
-	if len(config.DB.Host) != 0 {
-		conn = append(conn, "host="+config.DB.Host)
-	}
-
-	if len(config.DB.Port) != 0 {
-		conn = append(conn, "port="+config.DB.Port)
-	}
-
-	if len(config.DB.User) != 0 {
-		conn = append(conn, "user="+config.DB.User)
-	}
-
-	if len(config.DB.Password) != 0 {
-		conn = append(conn, "password="+config.DB.Password)
-	}
-
-	if len(config.DB.Name) != 0 {
-		conn = append(conn, "dbname="+config.DB.Name)
-	}
-
-	if len(config.DB.SslMode) != 0 {
-		conn = append(conn, "sslmode="+config.DB.SslMode)
-	}
-
-	return strings.Join(conn, " ")
-}
-
 func (p *PostgresDB) Close() {
 	p.Pool.Close()
 }
@@ -85,6 +55,12 @@ func (p *PostgresDB) Error(err error) error {
 		switch pgErr.Code {
 		case "23505":
 			return entity.ErrorConflict
+		// foreign key violation, e.g. a job whose owner does not exist
+		case "23503":
+			errValidation := entity.NewErrValidation()
+			errValidation.Errors[pgErr.ConstraintName] = "references a row that does not exist"
+			errValidation.Err = err
+			return errValidation
 		}
 	}
 
@@ -0,0 +1,178 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fifth_exam/job_service/internal/pkg/config"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/pgx"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	pgxv4 "github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/stdlib"
+)
+
+var migrationName = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// MigrationStatus is one migration of the source and whether it is applied
+type MigrationStatus struct {
+	Version uint
+	Name    string
+	Applied bool
+}
+
+// Migrator applies the migrations of a file system to the service database.
+// Every operation holds a postgres advisory lock so that replicas starting
+// at the same time apply the migrations one after another.
+type Migrator struct {
+	db      *sql.DB
+	source  source.Driver
+	migrate *migrate.Migrate
+}
+
+func NewMigrator(config *config.Config, migrations fs.FS) (*Migrator, error) {
+	connConfig, err := pgxv4.ParseConfig(config.PostgresDSN())
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse db conifg: %s", err.Error())
+	}
+	db := stdlib.OpenDB(*connConfig)
+
+	driver, err := pgx.WithInstance(db, &pgx.Config{LockStrategy: pgx.LockStrategyAdvisory})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("unable to connect to db: %s", err.Error())
+	}
+
+	// migrate reads the source it is given, the status listing uses its own
+	src, err := iofs.New(migrations, ".")
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrations source: %w", err)
+	}
+	listing, err := iofs.New(migrations, ".")
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrations source: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", src, "pgx", driver)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrate: %w", err)
+	}
+
+	return &Migrator{db: db, source: listing, migrate: m}, nil
+}
+
+// Up applies every pending migration, it is not an error when there is none
+func (m *Migrator) Up() error {
+	if err := m.migrate.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// Down reverts the given number of applied migrations, all of them when
+// steps is not positive
+func (m *Migrator) Down(steps int) error {
+	var err error
+	if steps > 0 {
+		err = m.migrate.Steps(-steps)
+	} else {
+		err = m.migrate.Down()
+	}
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// Force sets the schema version without running migrations and clears the
+// dirty flag left by a failed migration
+func (m *Migrator) Force(version int) error {
+	return m.migrate.Force(version)
+}
+
+// Version returns the applied schema version, 0 when nothing is applied
+func (m *Migrator) Version() (version uint, dirty bool, err error) {
+	version, dirty, err = m.migrate.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
+
+// Status lists every migration of the source in order
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	current, _, err := m.Version()
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []MigrationStatus
+	version, err := m.source.First()
+	for err == nil {
+		name := strconv.FormatUint(uint64(version), 10)
+		if r, identifier, errRead := m.source.ReadUp(version); errRead == nil {
+			r.Close()
+			name = identifier
+		}
+		statuses = append(statuses, MigrationStatus{Version: version, Name: name, Applied: version <= current})
+
+		version, err = m.source.Next(version)
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	return statuses, nil
+}
+
+func (m *Migrator) Close() error {
+	sourceErr, dbErr := m.migrate.Close()
+	return errors.Join(sourceErr, dbErr, m.source.Close())
+}
+
+// CreateMigration writes empty up and down files named after the next
+// sequence number of dir and returns their paths
+func CreateMigration(dir, name string) (up, down string, err error) {
+	if !migrationName.MatchString(name) {
+		return "", "", fmt.Errorf("migration name %q must be lower case letters, digits and underscores", name)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", "", err
+	}
+
+	var last uint64
+	for _, entry := range entries {
+		prefix, _, ok := strings.Cut(entry.Name(), "_")
+		if !ok {
+			continue
+		}
+		if version, err := strconv.ParseUint(prefix, 10, 64); err == nil && version > last {
+			last = version
+		}
+	}
+
+	base := filepath.Join(dir, fmt.Sprintf("%06d_%s", last+1, name))
+	up, down = base+".up.sql", base+".down.sql"
+	for _, path := range []string{up, down} {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err != nil {
+			return "", "", err
+		}
+		f.Close()
+	}
+
+	return up, down, nil
+}
@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"fifth_exam/job_service/migrations"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/stretchr/testify/suite"
+)
+
+type MigrateTestSuite struct {
+	suite.Suite
+}
+
+func (s *MigrateTestSuite) TestEmbeddedMigrations() {
+	src, err := iofs.New(migrations.FS, ".")
+	s.Suite.NoError(err)
+	defer src.Close()
+
+	first, err := src.First()
+	s.Suite.NoError(err)
+	s.Suite.Equal(uint(1), first)
+}
+
+func (s *MigrateTestSuite) TestCreateMigration() {
+	dir := s.T().TempDir()
+	s.Suite.NoError(os.WriteFile(filepath.Join(dir, "000007_create_jobs_table.up.sql"), nil, 0o644))
+
+	up, down, err := CreateMigration(dir, "add_status")
+	s.Suite.NoError(err)
+	s.Suite.Equal(filepath.Join(dir, "000008_add_status.up.sql"), up)
+	s.Suite.Equal(filepath.Join(dir, "000008_add_status.down.sql"), down)
+	s.Suite.FileExists(up)
+	s.Suite.FileExists(down)
+
+	_, _, err = CreateMigration(dir, "Add Status")
+	s.Suite.Error(err)
+}
+
+func TestMigrateTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrateTestSuite))
+}
@@ -0,0 +1,123 @@
+// Package ratelimit implements token buckets kept per key, such as a caller
+// and the method it calls
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Quota refills Rate tokens per second up to Burst, every request takes one
+type Quota struct {
+	Rate  float64
+	Burst int
+}
+
+// ParseQuota parses a rate such as "10/s", "600/m" or "1000/h" optionally
+// followed by the burst, which defaults to the count of the rate
+func ParseQuota(values []string) (Quota, error) {
+	if len(values) == 0 || len(values) > 2 {
+		return Quota{}, fmt.Errorf("quota %q must be a rate such as 10/s optionally followed by a burst", strings.Join(values, ","))
+	}
+
+	count, unit, ok := strings.Cut(values[0], "/")
+	n, err := strconv.Atoi(count)
+	if !ok || err != nil || n <= 0 {
+		return Quota{}, fmt.Errorf("rate %q must be a positive count per s, m or h", values[0])
+	}
+
+	var per time.Duration
+	switch unit {
+	case "s":
+		per = time.Second
+	case "m":
+		per = time.Minute
+	case "h":
+		per = time.Hour
+	default:
+		return Quota{}, fmt.Errorf("rate %q must be a positive count per s, m or h", values[0])
+	}
+
+	quota := Quota{Rate: float64(n) / per.Seconds(), Burst: n}
+	if len(values) == 2 {
+		burst, err := strconv.Atoi(values[1])
+		if err != nil || burst <= 0 {
+			return Quota{}, fmt.Errorf("burst %q must be a positive integer", values[1])
+		}
+		quota.Burst = burst
+	}
+	return quota, nil
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Limiter holds a token bucket per key, buckets idle for longer than
+// idleTimeout are dropped
+type Limiter struct {
+	mu          sync.Mutex
+	buckets     map[string]*bucket
+	idleTimeout time.Duration
+	lastSweep   time.Time
+	now         func() time.Time
+}
+
+func NewLimiter(idleTimeout time.Duration) *Limiter {
+	return &Limiter{
+		buckets:     make(map[string]*bucket),
+		idleTimeout: idleTimeout,
+		now:         time.Now,
+	}
+}
+
+// Allow takes a token from the bucket of key, when none is left it returns
+// false and the time until the next token is available
+func (l *Limiter) Allow(key string, quota Quota) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(quota.Burst), last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(float64(quota.Burst), b.tokens+now.Sub(b.last).Seconds()*quota.Rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	wait := time.Duration((1 - b.tokens) / quota.Rate * float64(time.Second))
+	return false, wait
+}
+
+// Len returns the number of buckets currently kept
+func (l *Limiter) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.buckets)
+}
+
+func (l *Limiter) sweep(now time.Time) {
+	if l.idleTimeout <= 0 || now.Sub(l.lastSweep) < l.idleTimeout {
+		return
+	}
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= l.idleTimeout {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
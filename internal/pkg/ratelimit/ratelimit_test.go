@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LimiterTestSuite struct {
+	suite.Suite
+	Now     time.Time
+	Limiter *Limiter
+}
+
+func (s *LimiterTestSuite) SetupTest() {
+	s.Now = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	s.Limiter = NewLimiter(time.Minute)
+	s.Limiter.now = func() time.Time { return s.Now }
+}
+
+func (s *LimiterTestSuite) TestAllow() {
+	quota := Quota{Rate: 2, Burst: 3}
+
+	for i := 0; i < 3; i++ {
+		ok, _ := s.Limiter.Allow("a", quota)
+		s.Suite.True(ok)
+	}
+	ok, wait := s.Limiter.Allow("a", quota)
+	s.Suite.False(ok)
+	s.Suite.Equal(500*time.Millisecond, wait)
+
+	// other keys have their own bucket
+	ok, _ = s.Limiter.Allow("b", quota)
+	s.Suite.True(ok)
+
+	s.Now = s.Now.Add(500 * time.Millisecond)
+	ok, _ = s.Limiter.Allow("a", quota)
+	s.Suite.True(ok)
+	ok, _ = s.Limiter.Allow("a", quota)
+	s.Suite.False(ok)
+}
+
+func (s *LimiterTestSuite) TestIdleBucketsAreDropped() {
+	s.Limiter.Allow("a", Quota{Rate: 1, Burst: 1})
+	s.Suite.Equal(1, s.Limiter.Len())
+
+	s.Now = s.Now.Add(2 * time.Minute)
+	s.Limiter.Allow("b", Quota{Rate: 1, Burst: 1})
+	s.Suite.Equal(1, s.Limiter.Len())
+}
+
+func (s *LimiterTestSuite) TestParseQuota() {
+	quota, err := ParseQuota([]string{"10/s"})
+	s.Suite.NoError(err)
+	s.Suite.Equal(Quota{Rate: 10, Burst: 10}, quota)
+
+	quota, err = ParseQuota([]string{"120/m", "5"})
+	s.Suite.NoError(err)
+	s.Suite.Equal(Quota{Rate: 2, Burst: 5}, quota)
+
+	for _, invalid := range [][]string{nil, {"10"}, {"10/d"}, {"-1/s"}, {"1/s", "0"}, {"1/s", "2", "3"}} {
+		_, err = ParseQuota(invalid)
+		s.Suite.Error(err, invalid)
+	}
+}
+
+func TestLimiterTestSuite(t *testing.T) {
+	suite.Run(t, new(LimiterTestSuite))
+}
@@ -0,0 +1,47 @@
+// Package requestid carries the id of a request through its context so that
+// every log line it produces can be correlated
+package requestid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Header is the metadata key the id is read from and returned in
+const Header = "x-request-id"
+
+// maxLength bounds ids supplied by callers
+const maxLength = 128
+
+type key struct{}
+
+// New returns a random request id
+func New() string {
+	return uuid.NewString()
+}
+
+// Valid reports whether an id supplied by a caller may be used as is, it
+// must be short and printable ASCII
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// WithID returns a copy of ctx carrying id
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, key{}, id)
+}
+
+// FromContext returns the request id of ctx or an empty string
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(key{}).(string)
+	return id
+}
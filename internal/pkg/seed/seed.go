@@ -0,0 +1,172 @@
+// Package seed generates and inserts development fixtures. The mock data of
+// the first migrations is deleted by a later one, environments that need
+// sample clients and jobs run the seed command instead.
+package seed
+
+import (
+	"context"
+	"fifth_exam/job_service/internal/entity"
+	"fifth_exam/job_service/internal/pkg/postgres"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	clientsTableName = "clients"
+	jobsTableName    = "jobs"
+
+	// batchSize bounds the rows of one insert statement
+	batchSize  = 500
+	dateLayout = "2006-01-02"
+)
+
+var (
+	firstNames = []string{"John", "Jane", "Michael", "Emily", "Christopher", "Amanda", "David", "Jessica", "Kevin", "Michelle", "Aziz", "Madina", "Timur", "Nodira", "Sardor", "Laylo"}
+	lastNames  = []string{"Doe", "Smith", "Johnson", "Brown", "Lee", "Wilson", "Martinez", "Taylor", "Harris", "Clark", "Karimov", "Yusupova", "Rashidov", "Aliyeva"}
+	streets    = []string{"Main", "Elm", "Oak", "Pine", "Maple", "Cedar", "Birch", "Walnut", "Amir Temur", "Navoi", "Bobur", "Mustaqillik"}
+	cities     = []string{"Anytown", "Othertown", "Somewhere", "Tashkent", "Samarkand", "Bukhara", "Andijan", "Namangan"}
+	positions  = []struct{ title, description string }{
+		{"Software Engineer", "Developing new features for a web application"},
+		{"Data Analyst", "Analyzing data trends and generating reports"},
+		{"Marketing Specialist", "Creating marketing campaigns and strategies"},
+		{"Graphic Designer", "Designing logos, banners, and promotional materials"},
+		{"Project Manager", "Overseeing project timelines and deliverables"},
+		{"Customer Support Representative", "Providing assistance to customers via phone and email"},
+		{"Content Writer", "Producing engaging content for blogs and social media"},
+		{"Sales Executive", "Identifying and contacting potential clients"},
+		{"HR Coordinator", "Managing recruitment processes and employee relations"},
+		{"Financial Analyst", "Analyzing financial data and preparing forecasts"},
+	}
+
+	// epoch anchors generated dates so that a seed always yields the same rows
+	epoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// Data is a set of clients and the jobs they own
+type Data struct {
+	Clients []entity.Client
+	Jobs    []entity.Job
+}
+
+// Generate returns the given number of clients and jobs, the same seed
+// always produces the same data. Jobs are spread over the generated clients,
+// no job is generated without clients.
+func Generate(seed int64, clients, jobCount int) Data {
+	rng := rand.New(rand.NewSource(seed))
+
+	var data Data
+	for i := 0; i < clients; i++ {
+		first, last := pick(rng, firstNames), pick(rng, lastNames)
+		created := epoch.Add(time.Duration(rng.Intn(365*24)) * time.Hour)
+		data.Clients = append(data.Clients, entity.Client{
+			Id:        newUUID(rng),
+			Username:  first + " " + last,
+			Email:     fmt.Sprintf("%s.%s.%d.%d@example.com", strings.ToLower(first), strings.ToLower(strings.ReplaceAll(last, " ", "")), seed, i),
+			Phone:     fmt.Sprintf("+998%09d", rng.Intn(1e9)),
+			Address:   fmt.Sprintf("%d %s Street, %s", 1+rng.Intn(999), pick(rng, streets), pick(rng, cities)),
+			CreatedAt: created,
+		})
+	}
+
+	if clients == 0 {
+		return data
+	}
+
+	for i := 0; i < jobCount; i++ {
+		job := positions[rng.Intn(len(positions))]
+		owner := data.Clients[rng.Intn(len(data.Clients))]
+		from := owner.CreatedAt.AddDate(0, 0, rng.Intn(60))
+		data.Jobs = append(data.Jobs, entity.Job{
+			Id:          newUUID(rng),
+			Title:       job.title,
+			Description: job.description,
+			OwnerId:     owner.Id,
+			Price:       float32(math.Round((50+rng.Float64()*4950)*100) / 100),
+			FromDate:    from.Format(dateLayout),
+			ToDate:      from.AddDate(0, 0, 7+rng.Intn(180)).Format(dateLayout),
+			CreatedAt:   from.Add(-time.Duration(rng.Intn(72)) * time.Hour),
+		})
+	}
+
+	return data
+}
+
+// Insert writes the data in one transaction, rows that already exist are
+// skipped so seeding twice with the same seed is harmless. With truncate the
+// clients and jobs tables are emptied first.
+func Insert(ctx context.Context, db *postgres.PostgresDB, data Data, truncate bool) (clients, jobs int64, err error) {
+	err = db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if truncate {
+			if _, err := tx.Exec(ctx, "TRUNCATE "+jobsTableName+", "+clientsTableName); err != nil {
+				return db.Error(err)
+			}
+		}
+
+		for start := 0; start < len(data.Clients); start += batchSize {
+			query := db.Sq.Builder.Insert(clientsTableName).
+				Columns("id", "username", "email", "phone", "address", "created_at").
+				Suffix("ON CONFLICT DO NOTHING")
+			for _, c := range data.Clients[start:min(start+batchSize, len(data.Clients))] {
+				query = query.Values(c.Id, c.Username, c.Email, c.Phone, c.Address, c.CreatedAt)
+			}
+			n, err := exec(ctx, db, tx, query, clientsTableName)
+			if err != nil {
+				return err
+			}
+			clients += n
+		}
+
+		for start := 0; start < len(data.Jobs); start += batchSize {
+			query := db.Sq.Builder.Insert(jobsTableName).
+				Columns("id", "title", "description", "owner_id", "price", "from_date", "to_date", "created_at").
+				Suffix("ON CONFLICT DO NOTHING")
+			for _, j := range data.Jobs[start:min(start+batchSize, len(data.Jobs))] {
+				query = query.Values(j.Id, j.Title, j.Description, j.OwnerId, j.Price, j.FromDate, j.ToDate, j.CreatedAt)
+			}
+			n, err := exec(ctx, db, tx, query, jobsTableName)
+			if err != nil {
+				return err
+			}
+			jobs += n
+		}
+
+		return nil
+	})
+	return clients, jobs, err
+}
+
+type sqlizer interface {
+	ToSql() (string, []interface{}, error)
+}
+
+func exec(ctx context.Context, db *postgres.PostgresDB, tx pgx.Tx, query sqlizer, table string) (int64, error) {
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, db.ErrSQLBuild(err, fmt.Sprintf("%s %s", table, "seed"))
+	}
+
+	tag, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, db.Error(err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+func pick(rng *rand.Rand, values []string) string {
+	return values[rng.Intn(len(values))]
+}
+
+func newUUID(rng *rand.Rand) string {
+	id, err := uuid.NewRandomFromReader(rng)
+	if err != nil {
+		// reading from math/rand never fails
+		panic(err)
+	}
+	return id.String()
+}
@@ -0,0 +1,44 @@
+package seed
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type SeedTestSuite struct {
+	suite.Suite
+}
+
+func (s *SeedTestSuite) TestGenerateIsDeterministic() {
+	s.Suite.Equal(Generate(7, 20, 50), Generate(7, 20, 50))
+	s.Suite.NotEqual(Generate(7, 20, 50), Generate(8, 20, 50))
+}
+
+func (s *SeedTestSuite) TestGenerate() {
+	data := Generate(1, 30, 100)
+	s.Suite.Len(data.Clients, 30)
+	s.Suite.Len(data.Jobs, 100)
+
+	owners := make(map[string]bool)
+	emails := make(map[string]bool)
+	for _, c := range data.Clients {
+		_, err := uuid.Parse(c.Id)
+		s.Suite.NoError(err)
+		s.Suite.False(emails[c.Email], "duplicate email %s", c.Email)
+		emails[c.Email] = true
+		owners[c.Id] = true
+	}
+	for _, j := range data.Jobs {
+		s.Suite.True(owners[j.OwnerId])
+		s.Suite.Less(j.FromDate, j.ToDate)
+		s.Suite.GreaterOrEqual(j.Price, float32(0))
+	}
+
+	s.Suite.Empty(Generate(1, 0, 10).Jobs)
+}
+
+func TestSeedTestSuite(t *testing.T) {
+	suite.Run(t, new(SeedTestSuite))
+}
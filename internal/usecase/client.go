@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fifth_exam/job_service/internal/entity"
+	"fifth_exam/job_service/internal/infrastructure/repository"
+	"fifth_exam/job_service/internal/pkg/auth"
+	logpkg "fifth_exam/job_service/internal/pkg/logger"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// ClientDeleteCascade deletes the open jobs of a deleted client and
+	// cancels the in-progress ones, finished jobs are kept as they are
+	ClientDeleteCascade = "cascade"
+	// ClientDeleteBlock refuses to delete a client with open or in-progress jobs
+	ClientDeleteBlock = "block"
+)
+
+var phonePattern = regexp.MustCompile(`^\+?[0-9]{7,15}$`)
+
+type Client interface {
+	Create(ctx context.Context, req *entity.Client) (*entity.Client, error)
+	Get(ctx context.Context, field, value string) (*entity.Client, error)
+	List(ctx context.Context, req *entity.GetListFilter) ([]*entity.Client, error)
+	Update(ctx context.Context, req *entity.Client) error
+	SetActive(ctx context.Context, field, value string, active bool) error
+	Delete(ctx context.Context, field, value string) error
+}
+
+type clientService struct {
+	BaseUseCase
+	repo         repository.Client
+	jobRepo      repository.Job
+	transactor   repository.Transactor
+	deletePolicy string
+	ctxTimeout   time.Duration
+	maxLimit     int64
+}
+
+// NewClientService returns the client usecase, deletePolicy is
+// ClientDeleteCascade or ClientDeleteBlock and decides what happens to the
+// jobs of deleted clients, lists return at most maxLimit clients
+func NewClientService(ctxTimeout time.Duration, repo repository.Client, jobRepo repository.Job,
+	transactor repository.Transactor, deletePolicy string, maxLimit int64) Client {
+	return &clientService{
+		repo:         repo,
+		jobRepo:      jobRepo,
+		transactor:   transactor,
+		deletePolicy: deletePolicy,
+		ctxTimeout:   ctxTimeout,
+		maxLimit:     maxLimit,
+	}
+}
+
+// Create validates and stores a new client, clients are active when created
+func (c *clientService) Create(ctx context.Context, req *entity.Client) (*entity.Client, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.ctxTimeout)
+	defer cancel()
+
+	if err := validateClient(req); err != nil {
+		return nil, err
+	}
+
+	c.BeforeRequest(&req.Id, &req.CreatedAt, nil)
+	req.IsActive = true
+
+	return c.repo.Create(ctx, req)
+}
+
+func (c *clientService) Get(ctx context.Context, field, value string) (*entity.Client, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.ctxTimeout)
+	defer cancel()
+
+	value, err := validateClientLookup(field, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.repo.Get(ctx, field, value)
+}
+
+func (c *clientService) List(ctx context.Context, req *entity.GetListFilter) ([]*entity.Client, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.ctxTimeout)
+	defer cancel()
+
+	filter, err := pageFilter(req, c.maxLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.repo.List(ctx, filter)
+}
+
+// Update replaces the fields of an existing client that is not deleted,
+// except for IsActive which only SetActive changes
+func (c *clientService) Update(ctx context.Context, req *entity.Client) error {
+	ctx, cancel := context.WithTimeout(ctx, c.ctxTimeout)
+	defer cancel()
+
+	if _, err := validateClientLookup("id", req.Id); err != nil {
+		return err
+	}
+	if err := authorizeClient(ctx, req.Id); err != nil {
+		return err
+	}
+	if err := validateClient(req); err != nil {
+		return err
+	}
+
+	c.BeforeRequest(&req.Id, nil, &req.UpdatedAt)
+
+	return c.repo.Update(ctx, req)
+}
+
+// authorizeClient allows a client to change only its own profile, admins can
+// change any client
+func authorizeClient(ctx context.Context, id string) error {
+	identity, ok := auth.FromContext(ctx)
+	if !ok {
+		return entity.NewErrPermissionDenied("caller is not authenticated")
+	}
+	if identity.Subject != id && !identity.IsAdmin() {
+		return entity.NewErrPermissionDenied("only the client itself or an admin can change it")
+	}
+	return nil
+}
+
+// SetActive activates or deactivates a client, inactive clients can't own
+// new jobs
+func (c *clientService) SetActive(ctx context.Context, field, value string, active bool) error {
+	ctx, cancel := context.WithTimeout(ctx, c.ctxTimeout)
+	defer cancel()
+
+	value, err := validateClientLookup(field, value)
+	if err != nil {
+		return err
+	}
+
+	return c.repo.SetActive(ctx, field, value, active)
+}
+
+func (c *clientService) Delete(ctx context.Context, field, value string) error {
+	ctx, cancel := context.WithTimeout(ctx, c.ctxTimeout)
+	defer cancel()
+
+	value, err := validateClientLookup(field, value)
+	if err != nil {
+		return err
+	}
+
+	return c.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// the lock keeps jobs from being given to the client until it is
+		// deleted, they would escape the policy
+		client, err := c.repo.GetForUpdate(ctx, field, value)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		switch c.deletePolicy {
+		case ClientDeleteBlock:
+			active, err := c.jobRepo.CountActiveByOwner(ctx, client.Id, now)
+			if err != nil {
+				return err
+			}
+			if active != 0 {
+				return entity.NewErrFailedPrecondition(fmt.Sprintf("client has %d open or in-progress jobs", active))
+			}
+		case ClientDeleteCascade:
+			deleted, err := c.jobRepo.DeleteOpenByOwner(ctx, client.Id, now)
+			if err != nil {
+				return err
+			}
+			cancelled, err := c.jobRepo.CancelInProgressByOwner(ctx, client.Id, now)
+			if err != nil {
+				return err
+			}
+			logpkg.FromContext(ctx).Info("client jobs cascaded",
+				zap.String("client_id", client.Id),
+				zap.Int64("deleted_jobs", deleted),
+				zap.Int64("cancelled_jobs", cancelled),
+			)
+		default:
+			return fmt.Errorf("unknown client delete policy %q", c.deletePolicy)
+		}
+
+		return c.repo.Delete(ctx, "id", client.Id)
+	})
+}
+
+func validateClient(req *entity.Client) error {
+	req.Username = strings.TrimSpace(req.Username)
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+	req.Phone = strings.TrimSpace(req.Phone)
+	req.Address = strings.TrimSpace(req.Address)
+
+	errValidation := entity.NewErrValidation()
+	if req.Username == "" {
+		errValidation.Errors["username"] = "is required"
+	}
+	if address, err := mail.ParseAddress(req.Email); err != nil || address.Address != req.Email {
+		errValidation.Errors["email"] = "must be a valid email address"
+	}
+	if !phonePattern.MatchString(req.Phone) {
+		errValidation.Errors["phone"] = "must be 7 to 15 digits with an optional leading +"
+	}
+	if req.Address == "" {
+		errValidation.Errors["address"] = "is required"
+	}
+	if len(errValidation.Errors) != 0 {
+		errValidation.Err = errors.New("invalid client")
+		return errValidation
+	}
+	return nil
+}
+
+// validateClientLookup only allows looking clients up by their unique columns and
+// returns the value normalized like the stored one
+func validateClientLookup(field, value string) (string, error) {
+	errValidation := entity.NewErrValidation()
+	switch field {
+	case "id":
+		if _, err := uuid.Parse(value); err != nil {
+			errValidation.Errors["value"] = "must be a valid uuid"
+		}
+	case "email":
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" {
+			errValidation.Errors["value"] = "is required"
+		}
+	default:
+		errValidation.Errors["field"] = "must be id or email"
+	}
+	if len(errValidation.Errors) != 0 {
+		errValidation.Err = errors.New("invalid client lookup")
+		return "", errValidation
+	}
+	return value, nil
+}
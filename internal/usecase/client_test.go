@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fifth_exam/job_service/internal/entity"
+	"fifth_exam/job_service/internal/pkg/auth"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type clientRepoStub struct {
+	clients map[string]*entity.Client
+	// locked and shared are the ids of the clients locked for update and
+	// for share
+	locked []string
+	shared []string
+}
+
+func (r *clientRepoStub) Create(ctx context.Context, req *entity.Client) (*entity.Client, error) {
+	r.clients[req.Id] = req
+	return req, nil
+}
+
+func (r *clientRepoStub) Get(ctx context.Context, field, value string) (*entity.Client, error) {
+	for _, client := range r.clients {
+		if (field == "id" && client.Id == value) || (field == "email" && client.Email == value) {
+			return client, nil
+		}
+	}
+	return nil, entity.NewErrNotFound("client")
+}
+
+func (r *clientRepoStub) GetForUpdate(ctx context.Context, field, value string) (*entity.Client, error) {
+	if !inTransaction(ctx) {
+		return nil, errors.New("row locked outside of a transaction")
+	}
+	client, err := r.Get(ctx, field, value)
+	if err != nil {
+		return nil, err
+	}
+	r.locked = append(r.locked, client.Id)
+	return client, nil
+}
+
+func (r *clientRepoStub) GetForShare(ctx context.Context, field, value string) (*entity.Client, error) {
+	if !inTransaction(ctx) {
+		return nil, errors.New("row locked outside of a transaction")
+	}
+	client, err := r.Get(ctx, field, value)
+	if err != nil {
+		return nil, err
+	}
+	r.shared = append(r.shared, client.Id)
+	return client, nil
+}
+
+func (r *clientRepoStub) List(ctx context.Context, req *entity.GetListFilter) ([]*entity.Client, error) {
+	return nil, nil
+}
+
+// Update leaves IsActive as it is, like the postgres repository
+func (r *clientRepoStub) Update(ctx context.Context, req *entity.Client) error {
+	client, ok := r.clients[req.Id]
+	if !ok {
+		return entity.NewErrNotFound("client")
+	}
+	req.IsActive = client.IsActive
+	r.clients[req.Id] = req
+	return nil
+}
+
+func (r *clientRepoStub) SetActive(ctx context.Context, field, value string, active bool) error {
+	client, err := r.Get(ctx, field, value)
+	if err != nil {
+		return err
+	}
+	client.IsActive = active
+	return nil
+}
+
+func (r *clientRepoStub) Delete(ctx context.Context, field, value string) error {
+	if _, ok := r.clients[value]; !ok {
+		return entity.NewErrNotFound("client")
+	}
+	delete(r.clients, value)
+	return nil
+}
+
+type txKey struct{}
+
+type transactorStub struct{}
+
+func (transactorStub) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(context.WithValue(ctx, txKey{}, true))
+}
+
+func inTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(bool)
+	return ok
+}
+
+type ClientTestSuite struct {
+	suite.Suite
+	Repo    *clientRepoStub
+	Jobs    *jobRepoStub
+	Usecase Client
+}
+
+func (s *ClientTestSuite) SetupTest() {
+	s.Repo = &clientRepoStub{clients: make(map[string]*entity.Client)}
+	s.Jobs = &jobRepoStub{jobs: make(map[string]*entity.Job)}
+	s.Usecase = NewClientService(time.Second, s.Repo, s.Jobs, transactorStub{}, ClientDeleteCascade, 100)
+}
+
+// addJobs gives the client a finished, an in-progress and an open job
+func (s *ClientTestSuite) addJobs(ownerId string) (finished, inProgress, open *entity.Job) {
+	now := time.Now()
+	job := func(from, to time.Time) *entity.Job {
+		job := &entity.Job{
+			Id:       uuid.NewString(),
+			OwnerId:  ownerId,
+			FromDate: from.Format(entity.JobDateLayout),
+			ToDate:   to.Format(entity.JobDateLayout),
+		}
+		s.Jobs.jobs[job.Id] = job
+		return job
+	}
+	return job(now.AddDate(0, -2, 0), now.AddDate(0, -1, 0)),
+		job(now.AddDate(0, 0, -1), now.AddDate(0, 0, 1)),
+		job(now.AddDate(0, 0, 7), now.AddDate(0, 1, 0))
+}
+
+func (s *ClientTestSuite) newClient() *entity.Client {
+	client, err := s.Usecase.Create(context.Background(), &entity.Client{
+		Username: "Jane Smith",
+		Email:    uuid.NewString() + "@example.com",
+		Phone:    "+998901234567",
+		Address:  "456 Elm Street",
+	})
+	s.Suite.Require().NoError(err)
+	return client
+}
+
+func (s *ClientTestSuite) TestCreate() {
+	ctx := context.Background()
+
+	client, err := s.Usecase.Create(ctx, &entity.Client{
+		Username: " John Doe ",
+		Email:    "John@Example.com",
+		Phone:    "+998901234567",
+		Address:  "123 Main Street",
+	})
+	s.Suite.NoError(err)
+	s.Suite.NotEmpty(client.Id)
+	s.Suite.True(client.IsActive)
+	s.Suite.Equal("John Doe", client.Username)
+
+	got, err := s.Usecase.Get(ctx, "email", "JOHN@example.com")
+	s.Suite.NoError(err)
+	s.Suite.Equal(client.Id, got.Id)
+}
+
+func (s *ClientTestSuite) TestValidation() {
+	ctx := context.Background()
+
+	_, err := s.Usecase.Create(ctx, &entity.Client{Email: "not an email", Phone: "12"})
+	var errValidation *entity.ErrValidation
+	s.Suite.ErrorAs(err, &errValidation)
+	s.Suite.Len(errValidation.Errors, 4)
+
+	_, err = s.Usecase.Get(ctx, "username; DROP TABLE clients", "x")
+	s.Suite.ErrorAs(err, &errValidation)
+	s.Suite.Contains(errValidation.Errors, "field")
+
+	err = s.Usecase.Delete(ctx, "id", "not-a-uuid")
+	s.Suite.ErrorAs(err, &errValidation)
+}
+
+func (s *ClientTestSuite) TestUpdateKeepsActive() {
+	client := s.newClient()
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{Subject: client.Id})
+
+	s.Suite.NoError(s.Usecase.SetActive(ctx, "id", client.Id, false))
+	s.Suite.NoError(s.Usecase.Update(ctx, &entity.Client{
+		Id:       client.Id,
+		Username: "Jane Doe",
+		Email:    client.Email,
+		Phone:    client.Phone,
+		Address:  client.Address,
+		IsActive: true,
+	}))
+
+	got, err := s.Usecase.Get(ctx, "id", client.Id)
+	s.Suite.NoError(err)
+	s.Suite.Equal("Jane Doe", got.Username)
+	s.Suite.False(got.IsActive)
+
+	s.Suite.NoError(s.Usecase.SetActive(ctx, "email", strings.ToUpper(client.Email), true))
+	got, err = s.Usecase.Get(ctx, "id", client.Id)
+	s.Suite.NoError(err)
+	s.Suite.True(got.IsActive)
+}
+
+func (s *ClientTestSuite) TestUpdateSelfOrAdmin() {
+	client, other := s.newClient(), s.newClient()
+	self := auth.WithIdentity(context.Background(), &auth.Identity{Subject: client.Id, Roles: []string{auth.RoleClient}})
+	stranger := auth.WithIdentity(context.Background(), &auth.Identity{Subject: other.Id, Roles: []string{auth.RoleClient}})
+	admin := auth.WithIdentity(context.Background(), &auth.Identity{Subject: "admin-1", Roles: []string{auth.RoleAdmin}})
+
+	update := func(username string) *entity.Client {
+		return &entity.Client{
+			Id:       client.Id,
+			Username: username,
+			Email:    client.Email,
+			Phone:    client.Phone,
+			Address:  client.Address,
+		}
+	}
+
+	var errPermission *entity.ErrPermissionDenied
+	s.Suite.ErrorAs(s.Usecase.Update(context.Background(), update("Mallory")), &errPermission)
+	s.Suite.ErrorAs(s.Usecase.Update(stranger, update("Mallory")), &errPermission)
+	s.Suite.Equal("Jane Smith", s.Repo.clients[client.Id].Username)
+
+	s.Suite.NoError(s.Usecase.Update(self, update("Jane Doe")))
+	s.Suite.Equal("Jane Doe", s.Repo.clients[client.Id].Username)
+	s.Suite.NoError(s.Usecase.Update(admin, update("Jane Roe")))
+	s.Suite.Equal("Jane Roe", s.Repo.clients[client.Id].Username)
+}
+
+func (s *ClientTestSuite) TestDeleteCascade() {
+	client := s.newClient()
+	finished, inProgress, open := s.addJobs(client.Id)
+
+	s.Suite.NoError(s.Usecase.Delete(context.Background(), "id", client.Id))
+
+	s.Suite.Equal([]string{client.Id}, s.Repo.locked)
+	s.Suite.NotContains(s.Repo.clients, client.Id)
+	s.Suite.True(finished.DeletedAt.IsZero())
+	s.Suite.True(finished.CancelledAt.IsZero())
+	s.Suite.Equal(entity.JobStatusCancelled, inProgress.Status(time.Now()))
+	s.Suite.False(open.DeletedAt.IsZero())
+}
+
+func (s *ClientTestSuite) TestDeleteBlock() {
+	s.Usecase = NewClientService(time.Second, s.Repo, s.Jobs, transactorStub{}, ClientDeleteBlock, 100)
+	client := s.newClient()
+	_, inProgress, open := s.addJobs(client.Id)
+
+	var errPrecondition *entity.ErrFailedPrecondition
+	err := s.Usecase.Delete(context.Background(), "id", client.Id)
+	s.Suite.ErrorAs(err, &errPrecondition)
+	s.Suite.Contains(s.Repo.clients, client.Id)
+	s.Suite.Equal([]string{client.Id}, s.Repo.locked)
+
+	delete(s.Jobs.jobs, inProgress.Id)
+	delete(s.Jobs.jobs, open.Id)
+	s.Suite.NoError(s.Usecase.Delete(context.Background(), "id", client.Id))
+}
+
+func TestClientTestSuite(t *testing.T) {
+	suite.Run(t, new(ClientTestSuite))
+}
@@ -2,6 +2,7 @@ package event
 
 import (
 	"context"
+	"time"
 )
 
 type ConsumerConfig interface {
@@ -14,5 +15,27 @@ type ConsumerConfig interface {
 type BrokerConsumer interface {
 	Run() error
 	RegisterConsumer(config ConsumerConfig)
+	// Consumers reports the state of every registered consumer
+	Consumers() []ConsumerStatus
 	Close()
-}
\ No newline at end of file
+}
+
+// ConsumerStatus describes a registered consumer, the counters are the
+// messages handled since it started. Failed counts every failed attempt,
+// Skipped the messages committed without being handled.
+type ConsumerStatus struct {
+	Topic         string    `json:"topic"`
+	GroupID       string    `json:"group_id"`
+	Brokers       []string  `json:"brokers"`
+	Running       bool      `json:"running"`
+	Handled       int64     `json:"handled"`
+	Failed        int64     `json:"failed"`
+	Skipped       int64     `json:"skipped"`
+	LastOffset    int64     `json:"last_offset"`
+	LastMessageAt time.Time `json:"last_message_at"`
+}
+
+type BrokerProducer interface {
+	Produce(ctx context.Context, topic string, key, value []byte) error
+	Close()
+}
@@ -0,0 +1,29 @@
+package event
+
+import "errors"
+
+// skipError marks a message its handler can never handle, e.g. an invalid
+// one, consumers commit it instead of retrying it
+type skipError struct {
+	err error
+}
+
+func (e *skipError) Error() string {
+	return e.err.Error()
+}
+
+func (e *skipError) Unwrap() error {
+	return e.err
+}
+
+// Skip wraps the error of a handler that retrying can't fix, the message is
+// counted as skipped and committed
+func Skip(err error) error {
+	return &skipError{err: err}
+}
+
+// Skipped reports whether err, or an error it wraps, was returned by Skip
+func Skipped(err error) bool {
+	var skip *skipError
+	return errors.As(err, &skip)
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"errors"
+	"fifth_exam/job_service/internal/entity"
+	"fmt"
+)
+
+// pageFilter returns a copy of req with the page size bounded by maxLimit,
+// requests without a limit get maxLimit and larger limits are rejected
+func pageFilter(req *entity.GetListFilter, maxLimit int64) (*entity.GetListFilter, error) {
+	errValidation := entity.NewErrValidation()
+	if req.Page < 0 {
+		errValidation.Errors["page"] = "must not be negative"
+	}
+	if req.Limit < 0 || req.Limit > maxLimit {
+		errValidation.Errors["limit"] = fmt.Sprintf("must be between 0 and %d", maxLimit)
+	}
+	if len(errValidation.Errors) != 0 {
+		errValidation.Err = errors.New("invalid page")
+		return nil, errValidation
+	}
+
+	filter := *req
+	if filter.Limit == 0 {
+		filter.Limit = maxLimit
+	}
+	return &filter, nil
+}
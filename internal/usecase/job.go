@@ -2,24 +2,20 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fifth_exam/job_service/internal/entity"
 	"fifth_exam/job_service/internal/infrastructure/repository"
-	"fifth_exam/job_service/internal/pkg/otlp"
+	"fifth_exam/job_service/internal/pkg/auth"
 	"time"
 
-	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/trace"
-)
-
-const (
-	serviceNameJob = "jobService"
-	spanNameJob    = "jobUsecase"
+	"github.com/google/uuid"
 )
 
 type Job interface {
 	Create(ctx context.Context, req *entity.Job) (*entity.Job, error)
 	Get(ctx context.Context, field, value string) (*entity.Job, error)
 	List(ctx context.Context, req *entity.GetListFilter) ([]*entity.Job, error)
+	ListByOwner(ctx context.Context, ownerId string, req *entity.GetListFilter) ([]*entity.Job, error)
 	Update(ctx context.Context, req *entity.Job) error
 	Delete(ctx context.Context, field, value string) error
 }
@@ -27,72 +23,189 @@ type Job interface {
 type jobService struct {
 	BaseUseCase
 	repo       repository.Job
+	clientRepo repository.Client
+	transactor repository.Transactor
 	ctxTimeout time.Duration
+	maxLimit   int64
 }
 
-func NewJobService(ctxTimeout time.Duration, repo repository.Job) Job {
+// NewJobService returns the job usecase, clientRepo is used to check that the
+// owner of a created or updated job is an existing active client, within the
+// transaction storing the job, and lists return at most maxLimit jobs
+func NewJobService(ctxTimeout time.Duration, repo repository.Job, clientRepo repository.Client,
+	transactor repository.Transactor, maxLimit int64) Job {
 	return &jobService{
 		repo:       repo,
+		clientRepo: clientRepo,
+		transactor: transactor,
 		ctxTimeout: ctxTimeout,
+		maxLimit:   maxLimit,
 	}
 }
 
 func (j *jobService) Create(ctx context.Context, req *entity.Job) (*entity.Job, error) {
 	ctx, cancel := context.WithTimeout(ctx, j.ctxTimeout)
 	defer cancel()
-	ctx, span := otlp.Start(ctx, serviceNameJob, spanNameJob+"Create")
-	defer span.End()
 
-	span.SetAttributes(attribute.KeyValue{Key: "repo", Value: attribute.StringValue("Creating job")})
-	ctxWithSpan := trace.ContextWithSpan(ctx, span)
+	var job *entity.Job
+	err := j.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := j.validateOwner(ctx, req.OwnerId); err != nil {
+			return err
+		}
+
+		var err error
+		job, err = j.repo.Create(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	return j.repo.Create(ctxWithSpan, req)
+	return job, nil
 }
 
 func (j *jobService) Get(ctx context.Context, field, value string) (*entity.Job, error) {
 	ctx, cancel := context.WithTimeout(ctx, j.ctxTimeout)
 	defer cancel()
-	ctx, span := otlp.Start(ctx, serviceNameJob, spanNameJob+"Get")
-	defer span.End()
-
-	span.SetAttributes(attribute.KeyValue{Key: "usecase", Value: attribute.StringValue("Getting job")})
-	ctxWithSpan := trace.ContextWithSpan(ctx, span)
 
-	return j.repo.Get(ctxWithSpan, field, value)
+	return j.repo.Get(ctx, field, value)
 }
 
 func (j *jobService) List(ctx context.Context, req *entity.GetListFilter) ([]*entity.Job, error) {
 	ctx, cancel := context.WithTimeout(ctx, j.ctxTimeout)
 	defer cancel()
-	ctx, span := otlp.Start(ctx, serviceNameJob, spanNameJob+"List")
-	defer span.End()
 
-	span.SetAttributes(attribute.KeyValue{Key: "usecase", Value: attribute.StringValue("Getting job list")})
-	ctxWithSpan := trace.ContextWithSpan(ctx, span)
+	filter, err := pageFilter(req, j.maxLimit)
+	if err != nil {
+		return nil, err
+	}
 
-	return j.repo.List(ctxWithSpan, req)
+	return j.repo.List(ctx, filter)
 }
 
-func (j *jobService) Update(ctx context.Context, req *entity.Job) error {
+// ListByOwner lists the jobs of an owner that is not deleted, jobs removed by
+// the deletion of their owner are therefore never listed. Only admins may
+// include deleted jobs.
+func (j *jobService) ListByOwner(ctx context.Context, ownerId string, req *entity.GetListFilter) ([]*entity.Job, error) {
 	ctx, cancel := context.WithTimeout(ctx, j.ctxTimeout)
 	defer cancel()
-	ctx, span := otlp.Start(ctx, serviceNameJob, spanNameJob+"Update")
-	defer span.End()
 
-	span.SetAttributes(attribute.KeyValue{Key: "usecase", Value: attribute.StringValue("Updating job")})
-	ctxWithSpan := trace.ContextWithSpan(ctx, span)
+	filter, err := pageFilter(req, j.maxLimit)
+	if err != nil {
+		return nil, err
+	}
+	if filter.IncludeDeleted {
+		if identity, ok := auth.FromContext(ctx); !ok || !identity.IsAdmin() {
+			return nil, entity.NewErrPermissionDenied("only admins can list deleted jobs")
+		}
+	}
+
+	if _, err := j.owner(ctx, ownerId, j.clientRepo.Get); err != nil {
+		return nil, err
+	}
+	filter.OwnerId = ownerId
+
+	return j.repo.List(ctx, filter)
+}
+
+// Update and Delete authorize the caller against the job locked in their
+// transaction so that its owner can't change before it is written
+func (j *jobService) Update(ctx context.Context, req *entity.Job) error {
+	ctx, cancel := context.WithTimeout(ctx, j.ctxTimeout)
+	defer cancel()
 
-	return j.repo.Update(ctxWithSpan, req)
+	return j.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		job, err := j.repo.GetForUpdate(ctx, "id", req.Id)
+		if err != nil {
+			return err
+		}
+		identity, err := authorize(ctx, job)
+		if err != nil {
+			return err
+		}
+		if req.OwnerId != job.OwnerId && !identity.IsAdmin() {
+			return entity.NewErrPermissionDenied("only admins can transfer a job to another owner")
+		}
+
+		if err := j.validateOwner(ctx, req.OwnerId); err != nil {
+			return err
+		}
+
+		return j.repo.Update(ctx, req)
+	})
 }
 
 func (j *jobService) Delete(ctx context.Context, field, value string) error {
 	ctx, cancel := context.WithTimeout(ctx, j.ctxTimeout)
 	defer cancel()
-	ctx, span := otlp.Start(ctx, serviceNameJob, spanNameJob+"Delete")
-	defer span.End()
 
-	span.SetAttributes(attribute.KeyValue{Key: "usecase", Value: attribute.StringValue("Deleting job")})
-	ctxWithSpan := trace.ContextWithSpan(ctx, span)
+	return j.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		job, err := j.repo.GetForUpdate(ctx, field, value)
+		if err != nil {
+			return err
+		}
+		if _, err := authorize(ctx, job); err != nil {
+			return err
+		}
+
+		// only the job that was authorized is deleted even if field is not
+		// unique
+		return j.repo.Delete(ctx, "id", job.Id)
+	})
+}
+
+// authorize allows the owner of the job and admins, the caller identity is
+// put into the context by the authentication interceptor
+func authorize(ctx context.Context, job *entity.Job) (*auth.Identity, error) {
+	identity, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, entity.NewErrPermissionDenied("caller is not authenticated")
+	}
+	if identity.Subject != job.OwnerId && !identity.IsAdmin() {
+		return nil, entity.NewErrPermissionDenied("only the owner of the job or an admin can change it")
+	}
+	return identity, nil
+}
+
+// validateOwner checks that ownerId is the id of a client that exists, is not
+// deleted and is active
+func (j *jobService) validateOwner(ctx context.Context, ownerId string) error {
+	// the owner is kept from being deactivated or deleted, and its delete
+	// policy from missing the job, until the transaction of ctx ends
+	owner, err := j.owner(ctx, ownerId, j.clientRepo.GetForShare)
+	if err != nil {
+		return err
+	}
+
+	if !owner.IsActive {
+		errValidation := entity.NewErrValidation()
+		errValidation.Err = errors.New("invalid job owner")
+		errValidation.Errors["owner_id"] = "client is not active"
+		return errValidation
+	}
+
+	return nil
+}
+
+// owner returns the client with id ownerId unless it is missing or deleted,
+// get reads it from the client repository
+func (j *jobService) owner(ctx context.Context, ownerId string,
+	get func(ctx context.Context, field, value string) (*entity.Client, error)) (*entity.Client, error) {
+	if _, err := uuid.Parse(ownerId); err != nil {
+		errValidation := entity.NewErrValidation()
+		errValidation.Err = errors.New("invalid job owner")
+		errValidation.Errors["owner_id"] = "must be a valid uuid"
+		return nil, errValidation
+	}
+
+	owner, err := get(ctx, "id", ownerId)
+	var errNotFound *entity.ErrNotFound
+	if errors.As(err, &errNotFound) {
+		return nil, entity.NewErrNotFound("owner " + ownerId)
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	return j.repo.Delete(ctxWithSpan, field, value)
+	return owner, nil
 }
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"fifth_exam/job_service/internal/entity"
+	"fifth_exam/job_service/internal/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type jobMetrics struct {
+	Job
+	created  prometheus.Counter
+	updated  prometheus.Counter
+	deleted  prometheus.Counter
+	failures *prometheus.CounterVec
+}
+
+// NewJobMetrics wraps a job usecase and counts the jobs it creates, updates
+// and deletes
+func NewJobMetrics(job Job, registerer prometheus.Registerer) Job {
+	m := &jobMetrics{
+		Job: job,
+		created: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Name:      "jobs_created_total",
+			Help:      "Jobs created.",
+		}),
+		updated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Name:      "jobs_updated_total",
+			Help:      "Jobs updated.",
+		}),
+		deleted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Name:      "jobs_deleted_total",
+			Help:      "Jobs deleted.",
+		}),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Name:      "job_operation_failures_total",
+			Help:      "Failed job operations, by operation.",
+		}, []string{"operation"}),
+	}
+	registerer.MustRegister(m.created, m.updated, m.deleted, m.failures)
+	return m
+}
+
+func (m *jobMetrics) Create(ctx context.Context, req *entity.Job) (*entity.Job, error) {
+	job, err := m.Job.Create(ctx, req)
+	if err != nil {
+		m.failures.WithLabelValues("create").Inc()
+		return job, err
+	}
+	m.created.Inc()
+	return job, nil
+}
+
+func (m *jobMetrics) Update(ctx context.Context, req *entity.Job) error {
+	if err := m.Job.Update(ctx, req); err != nil {
+		m.failures.WithLabelValues("update").Inc()
+		return err
+	}
+	m.updated.Inc()
+	return nil
+}
+
+func (m *jobMetrics) Delete(ctx context.Context, field, value string) error {
+	if err := m.Job.Delete(ctx, field, value); err != nil {
+		m.failures.WithLabelValues("delete").Inc()
+		return err
+	}
+	m.deleted.Inc()
+	return nil
+}
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fifth_exam/job_service/internal/entity"
+	"fifth_exam/job_service/internal/pkg/auth"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type jobRepoStub struct {
+	jobs   map[string]*entity.Job
+	listed *entity.GetListFilter
+	// locked are the ids of the jobs locked for update
+	locked []string
+}
+
+// Create, Update and Delete are made in the transaction that locked the
+// owner or the job
+func (r *jobRepoStub) Create(ctx context.Context, req *entity.Job) (*entity.Job, error) {
+	if !inTransaction(ctx) {
+		return nil, errors.New("job created outside of a transaction")
+	}
+	r.jobs[req.Id] = req
+	return req, nil
+}
+
+func (r *jobRepoStub) Get(ctx context.Context, field, value string) (*entity.Job, error) {
+	if job, ok := r.jobs[value]; ok {
+		return job, nil
+	}
+	return nil, entity.ErrorNotFound
+}
+
+func (r *jobRepoStub) GetForUpdate(ctx context.Context, field, value string) (*entity.Job, error) {
+	if !inTransaction(ctx) {
+		return nil, errors.New("row locked outside of a transaction")
+	}
+	job, err := r.Get(ctx, field, value)
+	if err != nil {
+		return nil, err
+	}
+	r.locked = append(r.locked, job.Id)
+	return job, nil
+}
+
+func (r *jobRepoStub) List(ctx context.Context, req *entity.GetListFilter) ([]*entity.Job, error) {
+	r.listed = req
+	return nil, nil
+}
+
+func (r *jobRepoStub) Update(ctx context.Context, req *entity.Job) error {
+	if !inTransaction(ctx) {
+		return errors.New("job updated outside of a transaction")
+	}
+	r.jobs[req.Id] = req
+	return nil
+}
+
+func (r *jobRepoStub) Delete(ctx context.Context, field, value string) error {
+	if !inTransaction(ctx) {
+		return errors.New("job deleted outside of a transaction")
+	}
+	delete(r.jobs, value)
+	return nil
+}
+
+func (r *jobRepoStub) DeleteOpenByOwner(ctx context.Context, ownerId string, now time.Time) (int64, error) {
+	return r.update(ownerId, now, entity.JobStatusOpen, func(job *entity.Job) { job.DeletedAt = now })
+}
+
+func (r *jobRepoStub) CancelInProgressByOwner(ctx context.Context, ownerId string, now time.Time) (int64, error) {
+	return r.update(ownerId, now, entity.JobStatusInProgress, func(job *entity.Job) { job.CancelledAt = now })
+}
+
+func (r *jobRepoStub) CountActiveByOwner(ctx context.Context, ownerId string, now time.Time) (int64, error) {
+	open, _ := r.update(ownerId, now, entity.JobStatusOpen, func(*entity.Job) {})
+	inProgress, _ := r.update(ownerId, now, entity.JobStatusInProgress, func(*entity.Job) {})
+	return open + inProgress, nil
+}
+
+func (r *jobRepoStub) update(ownerId string, now time.Time, status string, fn func(job *entity.Job)) (int64, error) {
+	var n int64
+	for _, job := range r.jobs {
+		if job.OwnerId == ownerId && job.DeletedAt.IsZero() && job.Status(now) == status {
+			fn(job)
+			n++
+		}
+	}
+	return n, nil
+}
+
+type JobTestSuite struct {
+	suite.Suite
+	Jobs    *jobRepoStub
+	Clients *clientRepoStub
+	Usecase Job
+}
+
+func (s *JobTestSuite) SetupTest() {
+	s.Jobs = &jobRepoStub{jobs: make(map[string]*entity.Job)}
+	s.Clients = &clientRepoStub{clients: make(map[string]*entity.Client)}
+	s.Usecase = NewJobService(time.Second, s.Jobs, s.Clients, transactorStub{}, 100)
+}
+
+func (s *JobTestSuite) addClient(active bool) string {
+	id := uuid.NewString()
+	s.Clients.clients[id] = &entity.Client{Id: id, IsActive: active}
+	return id
+}
+
+func (s *JobTestSuite) TestOwnerValidation() {
+	ctx := context.Background()
+	job := func(ownerId string) *entity.Job {
+		return &entity.Job{Id: uuid.NewString(), Title: "Software Engineer", OwnerId: ownerId}
+	}
+
+	ownerId := s.addClient(true)
+	_, err := s.Usecase.Create(ctx, job(ownerId))
+	s.Suite.NoError(err)
+	s.Suite.Equal([]string{ownerId}, s.Clients.shared)
+
+	var errValidation *entity.ErrValidation
+	_, err = s.Usecase.Create(ctx, job("not-a-uuid"))
+	s.Suite.ErrorAs(err, &errValidation)
+	s.Suite.Contains(errValidation.Errors, "owner_id")
+
+	_, err = s.Usecase.Create(ctx, job(s.addClient(false)))
+	s.Suite.ErrorAs(err, &errValidation)
+	s.Suite.Equal("client is not active", errValidation.Errors["owner_id"])
+
+	var errNotFound *entity.ErrNotFound
+	_, err = s.Usecase.Create(ctx, job(uuid.NewString()))
+	s.Suite.ErrorAs(err, &errNotFound)
+
+	err = s.Usecase.Update(ctx, job(uuid.NewString()))
+	s.Suite.ErrorAs(err, &errNotFound)
+
+	s.Suite.Len(s.Jobs.jobs, 1)
+}
+
+func (s *JobTestSuite) TestOwnerOrAdmin() {
+	ownerId := s.addClient(true)
+	job := &entity.Job{Id: uuid.NewString(), Title: "Data Analyst", OwnerId: ownerId}
+	s.Jobs.jobs[job.Id] = job
+
+	owner := auth.WithIdentity(context.Background(), &auth.Identity{Subject: ownerId})
+	stranger := auth.WithIdentity(context.Background(), &auth.Identity{Subject: s.addClient(true)})
+	admin := auth.WithIdentity(context.Background(), &auth.Identity{Subject: "admin-1", Roles: []string{auth.RoleAdmin}})
+
+	var errPermission *entity.ErrPermissionDenied
+	s.Suite.ErrorAs(s.Usecase.Update(context.Background(), job), &errPermission)
+	s.Suite.ErrorAs(s.Usecase.Update(stranger, job), &errPermission)
+	s.Suite.ErrorAs(s.Usecase.Delete(stranger, "id", job.Id), &errPermission)
+
+	s.Suite.NoError(s.Usecase.Update(owner, &entity.Job{Id: job.Id, Title: "Senior Data Analyst", OwnerId: ownerId}))
+
+	// only admins may hand a job over to another client
+	transfer := &entity.Job{Id: job.Id, Title: "Data Analyst", OwnerId: s.addClient(true)}
+	s.Suite.ErrorAs(s.Usecase.Update(owner, transfer), &errPermission)
+	s.Suite.NoError(s.Usecase.Update(admin, transfer))
+
+	s.Suite.NoError(s.Usecase.Delete(admin, "id", job.Id))
+	s.Suite.Empty(s.Jobs.jobs)
+
+	// every caller was authorized against the job locked in its transaction
+	s.Suite.Len(s.Jobs.locked, 7)
+	for _, id := range s.Jobs.locked {
+		s.Suite.Equal(job.Id, id)
+	}
+}
+
+func (s *JobTestSuite) TestListDeletedByOwnerIsAdminOnly() {
+	ownerId := s.addClient(true)
+	owner := auth.WithIdentity(context.Background(), &auth.Identity{Subject: ownerId, Roles: []string{auth.RoleClient}})
+	admin := auth.WithIdentity(context.Background(), &auth.Identity{Subject: "admin-1", Roles: []string{auth.RoleAdmin}})
+
+	var errPermission *entity.ErrPermissionDenied
+	_, err := s.Usecase.ListByOwner(owner, ownerId, &entity.GetListFilter{IncludeDeleted: true})
+	s.Suite.ErrorAs(err, &errPermission)
+	_, err = s.Usecase.ListByOwner(context.Background(), ownerId, &entity.GetListFilter{IncludeDeleted: true})
+	s.Suite.ErrorAs(err, &errPermission)
+
+	_, err = s.Usecase.ListByOwner(owner, ownerId, &entity.GetListFilter{})
+	s.Suite.NoError(err)
+	s.Suite.False(s.Jobs.listed.IncludeDeleted)
+
+	_, err = s.Usecase.ListByOwner(admin, ownerId, &entity.GetListFilter{IncludeDeleted: true})
+	s.Suite.NoError(err)
+	s.Suite.True(s.Jobs.listed.IncludeDeleted)
+}
+
+func (s *JobTestSuite) TestPageLimit() {
+	_, err := s.Usecase.List(context.Background(), &entity.GetListFilter{Page: 1})
+	s.Suite.NoError(err)
+	s.Suite.Equal(int64(100), s.Jobs.listed.Limit)
+
+	_, err = s.Usecase.List(context.Background(), &entity.GetListFilter{Page: 2, Limit: 20})
+	s.Suite.NoError(err)
+	s.Suite.Equal(int64(20), s.Jobs.listed.Limit)
+
+	var errValidation *entity.ErrValidation
+	_, err = s.Usecase.List(context.Background(), &entity.GetListFilter{Limit: 1000000})
+	s.Suite.ErrorAs(err, &errValidation)
+	s.Suite.Contains(errValidation.Errors, "limit")
+
+	_, err = s.Usecase.ListByOwner(context.Background(), s.addClient(true), &entity.GetListFilter{Limit: 101})
+	s.Suite.ErrorAs(err, &errValidation)
+}
+
+func TestJobTestSuite(t *testing.T) {
+	suite.Run(t, new(JobTestSuite))
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"fifth_exam/job_service/internal/entity"
+	"fifth_exam/job_service/internal/pkg/otlp"
+)
+
+type jobTracing struct {
+	next Job
+}
+
+// NewJobTracing wraps a job usecase and runs every call in a span named after
+// the method, e.g. "JobUsecase.Create", that records the error of failed
+// calls
+func NewJobTracing(next Job) Job {
+	return &jobTracing{next: next}
+}
+
+func (t *jobTracing) Create(ctx context.Context, req *entity.Job) (*entity.Job, error) {
+	return otlp.Trace(ctx, otlp.TracerUsecase, "JobUsecase.Create", func(ctx context.Context) (*entity.Job, error) {
+		return t.next.Create(ctx, req)
+	})
+}
+
+func (t *jobTracing) Get(ctx context.Context, field, value string) (*entity.Job, error) {
+	return otlp.Trace(ctx, otlp.TracerUsecase, "JobUsecase.Get", func(ctx context.Context) (*entity.Job, error) {
+		return t.next.Get(ctx, field, value)
+	})
+}
+
+func (t *jobTracing) List(ctx context.Context, req *entity.GetListFilter) ([]*entity.Job, error) {
+	return otlp.Trace(ctx, otlp.TracerUsecase, "JobUsecase.List", func(ctx context.Context) ([]*entity.Job, error) {
+		return t.next.List(ctx, req)
+	})
+}
+
+func (t *jobTracing) ListByOwner(ctx context.Context, ownerId string, req *entity.GetListFilter) ([]*entity.Job, error) {
+	return otlp.Trace(ctx, otlp.TracerUsecase, "JobUsecase.ListByOwner", func(ctx context.Context) ([]*entity.Job, error) {
+		return t.next.ListByOwner(ctx, ownerId, req)
+	})
+}
+
+func (t *jobTracing) Update(ctx context.Context, req *entity.Job) error {
+	return otlp.TraceError(ctx, otlp.TracerUsecase, "JobUsecase.Update", func(ctx context.Context) error {
+		return t.next.Update(ctx, req)
+	})
+}
+
+func (t *jobTracing) Delete(ctx context.Context, field, value string) error {
+	return otlp.TraceError(ctx, otlp.TracerUsecase, "JobUsecase.Delete", func(ctx context.Context) error {
+		return t.next.Delete(ctx, field, value)
+	})
+}
+
+type clientTracing struct {
+	next Client
+}
+
+// NewClientTracing is NewJobTracing for the client usecase
+func NewClientTracing(next Client) Client {
+	return &clientTracing{next: next}
+}
+
+func (t *clientTracing) Create(ctx context.Context, req *entity.Client) (*entity.Client, error) {
+	return otlp.Trace(ctx, otlp.TracerUsecase, "ClientUsecase.Create", func(ctx context.Context) (*entity.Client, error) {
+		return t.next.Create(ctx, req)
+	})
+}
+
+func (t *clientTracing) Get(ctx context.Context, field, value string) (*entity.Client, error) {
+	return otlp.Trace(ctx, otlp.TracerUsecase, "ClientUsecase.Get", func(ctx context.Context) (*entity.Client, error) {
+		return t.next.Get(ctx, field, value)
+	})
+}
+
+func (t *clientTracing) List(ctx context.Context, req *entity.GetListFilter) ([]*entity.Client, error) {
+	return otlp.Trace(ctx, otlp.TracerUsecase, "ClientUsecase.List", func(ctx context.Context) ([]*entity.Client, error) {
+		return t.next.List(ctx, req)
+	})
+}
+
+func (t *clientTracing) Update(ctx context.Context, req *entity.Client) error {
+	return otlp.TraceError(ctx, otlp.TracerUsecase, "ClientUsecase.Update", func(ctx context.Context) error {
+		return t.next.Update(ctx, req)
+	})
+}
+
+func (t *clientTracing) SetActive(ctx context.Context, field, value string, active bool) error {
+	return otlp.TraceError(ctx, otlp.TracerUsecase, "ClientUsecase.SetActive", func(ctx context.Context) error {
+		return t.next.SetActive(ctx, field, value, active)
+	})
+}
+
+func (t *clientTracing) Delete(ctx context.Context, field, value string) error {
+	return otlp.TraceError(ctx, otlp.TracerUsecase, "ClientUsecase.Delete", func(ctx context.Context) error {
+		return t.next.Delete(ctx, field, value)
+	})
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"fifth_exam/job_service/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type TracingTestSuite struct {
+	suite.Suite
+	Recorder *tracetest.SpanRecorder
+}
+
+func (s *TracingTestSuite) SetupTest() {
+	s.Recorder = tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.Recorder)))
+}
+
+func (s *TracingTestSuite) TestJobSpans() {
+	jobs := &jobRepoStub{jobs: map[string]*entity.Job{}}
+	usecase := NewJobTracing(NewJobService(time.Second, jobs, &clientRepoStub{clients: map[string]*entity.Client{}}, transactorStub{}, 100))
+
+	_, err := usecase.Get(context.Background(), "id", "missing")
+	s.Suite.Error(err)
+	_, err = usecase.List(context.Background(), &entity.GetListFilter{})
+	s.Suite.NoError(err)
+
+	spans := s.Recorder.Ended()
+	s.Suite.Require().Len(spans, 2)
+	s.Suite.Equal("JobUsecase.Get", spans[0].Name())
+	s.Suite.Equal(codes.Error, spans[0].Status().Code)
+	s.Suite.Equal("JobUsecase.List", spans[1].Name())
+	s.Suite.Equal(codes.Unset, spans[1].Status().Code)
+}
+
+func TestTracingTestSuite(t *testing.T) {
+	suite.Run(t, new(TracingTestSuite))
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_clients_deleted_at;
+
+ALTER TABLE clients DROP COLUMN IF EXISTS is_active;
@@ -0,0 +1,3 @@
+ALTER TABLE clients ADD COLUMN is_active BOOLEAN NOT NULL DEFAULT TRUE;
+
+CREATE INDEX idx_clients_deleted_at ON clients (deleted_at);
@@ -0,0 +1 @@
+ALTER TABLE jobs ADD COLUMN cancelled_at TIMESTAMP;
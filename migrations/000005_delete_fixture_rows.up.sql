@@ -0,0 +1,27 @@
+-- The mock data inserted by the first migrations, fixtures now come from the
+-- seed command. Fixture clients still owning other jobs are kept.
+DELETE FROM jobs WHERE id IN (
+    'f47ac10b-58cc-4372-a567-0e02b2c3d479',
+    'ac1f8087-cc21-4e62-b2f0-cb3c9a77b3e1',
+    '6af8b0c1-2e2c-4b5d-92d5-b0e8b29f65ad',
+    'e963c3b1-37e8-4c5e-b15d-38c47d85e8b1',
+    'da455e4a-7cb8-47bf-a0d2-18b74b5b5690',
+    '47d23bf0-2050-4d8c-810e-d2b8d66fc7a0',
+    'd0f8c0ef-24d1-4f3d-ae6a-43b01ad6b587',
+    '9c4e8f6e-4d17-4854-91e7-36b63d8006aa',
+    'ea95b085-702a-434d-823d-8d4059ef71e9',
+    'b13db871-8e32-48dc-9255-f31205b964c0'
+);
+
+DELETE FROM clients c WHERE c.id IN (
+    'f47ac10b-58cc-4372-a567-0e02b2c3d479',
+    'ac1f8087-cc21-4e62-b2f0-cb3c9a77b3e1',
+    '6af8b0c1-2e2c-4b5d-92d5-b0e8b29f65ad',
+    'e963c3b1-37e8-4c5e-b15d-38c47d85e8b1',
+    'da455e4a-7cb8-47bf-a0d2-18b74b5b5690',
+    '47d23bf0-2050-4d8c-810e-d2b8d66fc7a0',
+    'd0f8c0ef-24d1-4f3d-ae6a-43b01ad6b587',
+    '9c4e8f6e-4d17-4854-91e7-36b63d8006aa',
+    'ea95b085-702a-434d-823d-8d4059ef71e9',
+    'b13db871-8e32-48dc-9255-f31205b964c0'
+) AND NOT EXISTS (SELECT 1 FROM jobs j WHERE j.owner_id = c.id);
@@ -0,0 +1,8 @@
+// Package migrations embeds the SQL migrations of the service so that the
+// binary can apply them without the migrations directory on disk
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS